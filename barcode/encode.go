@@ -0,0 +1,122 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnencodable = errors.New("barcode cannot be encoded")
+
+// Bars adalah pola modul barcode dari kiri ke kanan tanpa quiet zone; true berarti garis hitam selebar satu modul.
+type Bars []bool
+
+// QuietZone adalah jumlah modul kosong minimal di kiri dan kanan barcode.
+const QuietZone = 10
+
+func (b Bars) appendWidths(widths string) Bars {
+	bar := true
+	for i := 0; i < len(widths); i++ {
+		for n := 0; n < int(widths[i]-'0'); n++ {
+			b = append(b, bar)
+		}
+		bar = !bar
+	}
+	return b
+}
+
+func (b Bars) appendModules(modules string) Bars {
+	for i := 0; i < len(modules); i++ {
+		b = append(b, modules[i] == '1')
+	}
+	return b
+}
+
+// Pola EAN: set L (ganjil), G (genap) dan R (sisi kanan) per digit
+var (
+	eanL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	eanR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+
+	// Paritas enam digit kiri ditentukan digit pertama
+	eanParity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+// EncodeEAN13 membuat pola 95 modul EAN-13. UPC-A 12 digit dikodekan sebagai EAN-13 dengan awalan 0.
+func EncodeEAN13(code string) (Bars, error) {
+	if ValidUPC(code) {
+		code = "0" + code
+	}
+	if !ValidEAN13(code) {
+		return nil, fmt.Errorf("%w: %s is not a valid EAN-13", ErrUnencodable, code)
+	}
+
+	bars := make(Bars, 0, 95).appendModules("101")
+	parity := eanParity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		d := code[i] - '0'
+		if parity[i-1] == 'L' {
+			bars = bars.appendModules(eanL[d])
+		} else {
+			bars = bars.appendModules(eanG[d])
+		}
+	}
+	bars = bars.appendModules("01010")
+	for i := 7; i <= 12; i++ {
+		bars = bars.appendModules(eanR[code[i]-'0'])
+	}
+	return bars.appendModules("101"), nil
+}
+
+// Lebar bar/spasi Code 128 untuk nilai 0-106 (106 = stop)
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// EncodeCode128 membuat pola Code 128. Teks angka dengan panjang genap memakai code set C yang lebih rapat,
+// selain itu code set B (ASCII 32-126).
+func EncodeCode128(text string) (Bars, error) {
+	if !ValidInternal(text) {
+		return nil, fmt.Errorf("%w: Code 128 needs 1-48 printable ASCII characters", ErrUnencodable)
+	}
+
+	var values []int
+	if len(text)%2 == 0 && isDigits(text) {
+		values = append(values, code128StartC)
+		for i := 0; i < len(text); i += 2 {
+			values = append(values, int(text[i]-'0')*10+int(text[i+1]-'0'))
+		}
+	} else {
+		values = append(values, code128StartB)
+		for i := 0; i < len(text); i++ {
+			values = append(values, int(text[i])-32)
+		}
+	}
+
+	checksum := values[0]
+	for i := 1; i < len(values); i++ {
+		checksum += values[i] * i
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	bars := make(Bars, 0, len(values)*11+2)
+	for _, v := range values {
+		bars = bars.appendWidths(code128Patterns[v])
+	}
+	return bars, nil
+}
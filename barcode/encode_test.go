@@ -0,0 +1,179 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func (b Bars) String() string {
+	var s strings.Builder
+	for _, bar := range b {
+		if bar {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
+
+func ones(modules string) int {
+	return strings.Count(modules, "1")
+}
+
+// Set L berparitas ganjil, R adalah komplemen L, dan G adalah R yang dibalik (berparitas genap).
+func TestEANPatternTables(t *testing.T) {
+	for d := 0; d < 10; d++ {
+		l, g, r := eanL[d], eanG[d], eanR[d]
+		if len(l) != 7 || len(g) != 7 || len(r) != 7 {
+			t.Fatalf("digit %d: pattern lengths %d/%d/%d, want 7", d, len(l), len(g), len(r))
+		}
+		if ones(l)%2 != 1 {
+			t.Errorf("digit %d: L pattern %s must have odd parity", d, l)
+		}
+		if ones(g)%2 != 0 {
+			t.Errorf("digit %d: G pattern %s must have even parity", d, g)
+		}
+		for i := 0; i < 7; i++ {
+			if l[i] == r[i] {
+				t.Errorf("digit %d: R pattern %s is not the complement of L %s", d, r, l)
+				break
+			}
+			if g[i] != r[6-i] {
+				t.Errorf("digit %d: G pattern %s is not R %s reversed", d, g, r)
+				break
+			}
+		}
+
+		// Digit pertama 0 (UPC-A) memakai set L semua, selain itu tepat tiga set G
+		wantG := 3
+		if d == 0 {
+			wantG = 0
+		}
+		if parity := eanParity[d]; len(parity) != 6 || parity[0] != 'L' || strings.Count(parity, "G") != wantG {
+			t.Errorf("digit %d: invalid parity %s", d, parity)
+		}
+	}
+}
+
+func TestEncodeEAN13(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"4006381333931", "10100011010100111010111101111010001001011001101010100001010000101000010111010010000101100110101"},
+		{"0036000291452", "10100011010111101010111100011010001101000110101010110110011101001100110101110010011101101100101"},
+		// UPC-A sama dengan EAN-13 berawalan 0
+		{"036000291452", "10100011010111101010111100011010001101000110101010110110011101001100110101110010011101101100101"},
+	}
+	for _, tt := range tests {
+		bars, err := EncodeEAN13(tt.code)
+		if err != nil {
+			t.Fatalf("EncodeEAN13(%s): %v", tt.code, err)
+		}
+		if len(bars) != 95 {
+			t.Errorf("EncodeEAN13(%s) has %d modules, want 95", tt.code, len(bars))
+		}
+		if got := bars.String(); got != tt.want {
+			t.Errorf("EncodeEAN13(%s) =\n%s\nwant\n%s", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeEAN13Invalid(t *testing.T) {
+	for _, code := range []string{"", "4006381333932", "036000291453", "400638133393", "400638133393A", "2000000000077X"} {
+		if _, err := EncodeEAN13(code); !errors.Is(err, ErrUnencodable) {
+			t.Errorf("EncodeEAN13(%q) error = %v, want ErrUnencodable", code, err)
+		}
+	}
+}
+
+// widths mengubah lebar bar/spasi berselang-seling menjadi string modul, mulai dari bar.
+func widths(w string) string {
+	var s strings.Builder
+	for i := 0; i < len(w); i++ {
+		module := "1"
+		if i%2 == 1 {
+			module = "0"
+		}
+		s.WriteString(strings.Repeat(module, int(w[i]-'0')))
+	}
+	return s.String()
+}
+
+func TestCode128PatternTable(t *testing.T) {
+	for v, p := range code128Patterns {
+		want, n := 11, 6
+		if v == code128Stop {
+			want, n = 13, 7
+		}
+		sum := 0
+		for i := 0; i < len(p); i++ {
+			sum += int(p[i] - '0')
+		}
+		if len(p) != n || sum != want {
+			t.Errorf("value %d: pattern %s has %d elements and %d modules, want %d and %d", v, p, len(p), sum, n, want)
+		}
+	}
+}
+
+func TestEncodeCode128(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string // Lebar bar/spasi: start, data, checksum, stop
+	}{
+		// Start C (105), 12, checksum (105 + 12*1) % 103 = 14
+		{"code set C", "12", "211232" + "112232" + "122231" + "2331112"},
+		// Start C, 20, 00, 07, checksum (105 + 20*1 + 0*2 + 7*3) % 103 = 43
+		{"code set C internal", "200007", "211232" + "221231" + "212222" + "122312" + "112331" + "2331112"},
+		// Start B (104), A=33, B=34, checksum (104 + 33*1 + 34*2) % 103 = 102
+		{"code set B", "AB", "211214" + "111323" + "131123" + "411131" + "2331112"},
+		// Angka dengan panjang ganjil tidak bisa memakai code set C: start B, 1=17, 2=18, 3=19, checksum 214 % 103 = 8
+		{"odd digits use code set B", "123", "211214" + "123221" + "223211" + "221132" + "132212" + "2331112"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars, err := EncodeCode128(tt.text)
+			if err != nil {
+				t.Fatalf("EncodeCode128(%q): %v", tt.text, err)
+			}
+			if got, want := bars.String(), widths(tt.want); got != want {
+				t.Errorf("EncodeCode128(%q) =\n%s\nwant\n%s", tt.text, got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeCode128Length(t *testing.T) {
+	tests := []struct {
+		text   string
+		values int // Start, data dan checksum tanpa stop
+	}{
+		{"1234", 1 + 2 + 1},
+		{"12345", 1 + 5 + 1},
+		{"SKU-001", 1 + 7 + 1},
+		{strings.Repeat("9", 48), 1 + 24 + 1},
+	}
+	for _, tt := range tests {
+		bars, err := EncodeCode128(tt.text)
+		if err != nil {
+			t.Fatalf("EncodeCode128(%q): %v", tt.text, err)
+		}
+		if want := tt.values*11 + 13; len(bars) != want {
+			t.Errorf("EncodeCode128(%q) has %d modules, want %d", tt.text, len(bars), want)
+		}
+		if !bars[0] || !bars[len(bars)-1] {
+			t.Errorf("EncodeCode128(%q) must start and end with a bar", tt.text)
+		}
+	}
+}
+
+func TestEncodeCode128Invalid(t *testing.T) {
+	for _, text := range []string{"", " SKU", "SKU ", "Kopi\tSusu", "Café", strings.Repeat("A", 49)} {
+		if _, err := EncodeCode128(text); !errors.Is(err, ErrUnencodable) {
+			t.Errorf("EncodeCode128(%q) error = %v, want ErrUnencodable", text, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package barcode berisi validasi nomor GTIN (EAN-13 / UPC-A) dan pembuatan barcode internal toko.
+package barcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// internalPrefix adalah prefix GS1 untuk nomor yang hanya berlaku di dalam toko (restricted circulation).
+const internalPrefix = "20"
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckDigit menghitung digit cek GS1 (modulo 10) untuk data tanpa digit cek.
+// Digit paling kanan diberi bobot 3, lalu bergantian 1 dan 3 ke kiri.
+func CheckDigit(data string) int {
+	sum := 0
+	for i := 0; i < len(data); i++ {
+		d := int(data[len(data)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// ValidEAN13 memeriksa 13 digit dan digit ceknya.
+func ValidEAN13(code string) bool {
+	return len(code) == 13 && isDigits(code) && CheckDigit(code[:12]) == int(code[12]-'0')
+}
+
+// ValidUPC memeriksa UPC-A 12 digit dan digit ceknya.
+func ValidUPC(code string) bool {
+	return len(code) == 12 && isDigits(code) && CheckDigit(code[:11]) == int(code[11]-'0')
+}
+
+// ValidInternal memeriksa kode internal: 1-48 karakter ASCII yang bisa dicetak sebagai Code128.
+func ValidInternal(code string) bool {
+	if code == "" || len(code) > 48 || strings.TrimSpace(code) != code {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 32 || code[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// Internal membuat EAN-13 internal dari nomor urut, mis. 7 menjadi 2000000000077.
+func Internal(seq int64) string {
+	data := fmt.Sprintf("%s%010d", internalPrefix, seq)
+	return fmt.Sprintf("%s%d", data, CheckDigit(data))
+}
@@ -0,0 +1,85 @@
+package barcode
+
+import "testing"
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		data string
+		want int
+	}{
+		{"400638133393", 1},
+		{"590123412345", 7},
+		{"03600029145", 2},
+		{"01234567890", 5},
+		{"200000000007", 7},
+		{"00000000000", 0},
+	}
+	for _, tt := range tests {
+		if got := CheckDigit(tt.data); got != tt.want {
+			t.Errorf("CheckDigit(%q) = %d, want %d", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestValidEAN13(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"4006381333931", true},
+		{"5901234123457", true},
+		{"0036000291452", true},
+		{"4006381333932", false},
+		{"400638133393", false},
+		{"40063813339310", false},
+		{"400638133393A", false},
+		{"4006381 33931", false},
+		{"036000291452", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidEAN13(tt.code); got != tt.want {
+			t.Errorf("ValidEAN13(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestValidUPC(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"036000291452", true},
+		{"012345678905", true},
+		{"036000291453", false},
+		{"03600029145", false},
+		{"4006381333931", false},
+		{"03600029145X", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidUPC(tt.code); got != tt.want {
+			t.Errorf("ValidUPC(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestInternal(t *testing.T) {
+	tests := []struct {
+		seq  int64
+		want string
+	}{
+		{1, "2000000000015"},
+		{7, "2000000000077"},
+		{1234567890, "2012345678903"},
+	}
+	for _, tt := range tests {
+		got := Internal(tt.seq)
+		if got != tt.want {
+			t.Errorf("Internal(%d) = %s, want %s", tt.seq, got, tt.want)
+		}
+		if !ValidEAN13(got) {
+			t.Errorf("Internal(%d) = %s is not a valid EAN-13", tt.seq, got)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+const defaultCartTTL = 30 * time.Minute
+
+// CartTTL membaca masa berlaku keranjang yang diparkir dari CART_TTL (durasi Go, mis. "45m" atau "2h", default 30m).
+// Masa berlaku diperpanjang setiap kali isi keranjang diubah; reservasi stok ikut berakhir bersamanya.
+func CartTTL() time.Duration {
+	v := os.Getenv("CART_TTL")
+	if v == "" {
+		return defaultCartTTL
+	}
+	ttl, err := time.ParseDuration(v)
+	if err != nil || ttl <= 0 {
+		log.Fatalf("Invalid CART_TTL %q: must be a positive duration such as 30m", v)
+	}
+	return ttl
+}
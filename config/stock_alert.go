@@ -0,0 +1,50 @@
+package config
+
+import (
+	"kasir-api/models"
+	"log"
+	"os"
+	"time"
+)
+
+const defaultLowStockInterval = time.Minute
+
+// StockAlertConfig membaca pengaturan alert stok menipis dari environment:
+//   - LOW_STOCK_NOTIFIER: log, webhook atau email (default log)
+//   - LOW_STOCK_WEBHOOK_URL: wajib jika notifier webhook
+//   - LOW_STOCK_EMAIL_TO: wajib jika notifier email
+//   - LOW_STOCK_CHECK_INTERVAL: durasi Go (default 1m)
+func StockAlertConfig() models.StockAlertConfig {
+	cfg := models.StockAlertConfig{
+		Notifier:   os.Getenv("LOW_STOCK_NOTIFIER"),
+		WebhookURL: os.Getenv("LOW_STOCK_WEBHOOK_URL"),
+		EmailTo:    os.Getenv("LOW_STOCK_EMAIL_TO"),
+		Interval:   defaultLowStockInterval,
+	}
+	if cfg.Notifier == "" {
+		cfg.Notifier = models.StockNotifierLog
+	}
+
+	switch cfg.Notifier {
+	case models.StockNotifierLog:
+	case models.StockNotifierWebhook:
+		if cfg.WebhookURL == "" {
+			log.Fatal("LOW_STOCK_WEBHOOK_URL is required when LOW_STOCK_NOTIFIER is webhook")
+		}
+	case models.StockNotifierEmail:
+		if cfg.EmailTo == "" {
+			log.Fatal("LOW_STOCK_EMAIL_TO is required when LOW_STOCK_NOTIFIER is email")
+		}
+	default:
+		log.Fatalf("Invalid LOW_STOCK_NOTIFIER %q: must be log, webhook or email", cfg.Notifier)
+	}
+
+	if v := os.Getenv("LOW_STOCK_CHECK_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			log.Fatalf("Invalid LOW_STOCK_CHECK_INTERVAL %q: must be a positive duration such as 1m", v)
+		}
+		cfg.Interval = interval
+	}
+	return cfg
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+	_ "time/tzdata" // Agar zona waktu tetap tersedia di container tanpa tzdata
+)
+
+const defaultStoreTimezone = "Asia/Jakarta"
+
+// StoreLocation membaca zona waktu toko dari STORE_TIMEZONE (default WIB / Asia/Jakarta).
+// Dipakai untuk menentukan batas "hari ini" pada laporan, bukan zona waktu server.
+func StoreLocation() *time.Location {
+	name := os.Getenv("STORE_TIMEZONE")
+	if name == "" {
+		name = defaultStoreTimezone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("Invalid STORE_TIMEZONE %q: %v", name, err)
+	}
+	return loc
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"kasir-api/models"
+	"log"
+	"os"
+	"strconv"
+)
+
+// Tanpa TAX_RATE tidak ada pajak, sehingga total checkout instalasi lama tidak berubah setelah upgrade
+const defaultTaxRate = 0
+
+// TaxConfig membaca pengaturan pajak dari environment:
+//   - TAX_RATE: tarif PPN default dalam persen (default 0, tidak ada pajak; isi 11 untuk PPN 11%)
+//   - TAX_INCLUSIVE: "true" jika harga produk sudah termasuk PPN (default false)
+//   - SERVICE_CHARGE_RATE: service charge dalam persen (default 0, tidak ada service charge)
+func TaxConfig() models.TaxConfig {
+	return models.TaxConfig{
+		DefaultRate:       percentEnv("TAX_RATE", defaultTaxRate),
+		PricesIncludeTax:  os.Getenv("TAX_INCLUSIVE") == "true",
+		ServiceChargeRate: percentEnv("SERVICE_CHARGE_RATE", 0),
+	}
+}
+
+func percentEnv(key string, fallback float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	rate, err := strconv.ParseFloat(v, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		log.Fatalf("Invalid %s %q: must be a percentage between 0 and 100", key, v)
+	}
+	return rate
+}
@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestTaxConfigDefaults(t *testing.T) {
+	t.Setenv("TAX_RATE", "")
+	t.Setenv("TAX_INCLUSIVE", "")
+	t.Setenv("SERVICE_CHARGE_RATE", "")
+
+	c := TaxConfig()
+	if c.DefaultRate != 0 || c.PricesIncludeTax || c.ServiceChargeRate != 0 {
+		t.Errorf("TaxConfig() = %+v, want no tax and no service charge without environment", c)
+	}
+
+	t.Setenv("TAX_RATE", "11")
+	if c := TaxConfig(); c.DefaultRate != 11 {
+		t.Errorf("TaxConfig().DefaultRate = %g with TAX_RATE=11, want 11", c.DefaultRate)
+	}
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CartController struct {
+	service *service.CartService
+}
+
+func NewCartController(service *service.CartService) *CartController {
+	return &CartController{service: service}
+}
+
+// CreateCart godoc
+// @Summary Buat keranjang (parkir pesanan)
+// @Description reserve_stock true menahan stok untuk keranjang ini sampai expires_at (CART_TTL, diperpanjang setiap isi keranjang berubah)
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param X-User header string false "Kasir yang membuat keranjang"
+// @Param cart body models.CreateCartRequest true "Cart Data"
+// @Success 201 {object} models.Cart
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts [post]
+func (h *CartController) CreateCart(c *gin.Context) {
+	var req models.CreateCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Cashier = c.GetHeader("X-User")
+
+	cart, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, cart)
+}
+
+// GetAllCarts godoc
+// @Summary Daftar keranjang
+// @Tags Carts
+// @Produce json
+// @Param status query string false "open, checked_out, expired atau cancelled"
+// @Success 200 {array} models.Cart
+// @Failure 400 {object} map[string]string
+// @Router /carts [get]
+func (h *CartController) GetAllCarts(c *gin.Context) {
+	carts, err := h.service.GetAll(c.Query("status"))
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, carts)
+}
+
+// GetCartByID godoc
+// @Summary Ambil keranjang dengan harga dan stok terkini
+// @Tags Carts
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Success 200 {object} models.Cart
+// @Failure 404 {object} map[string]string
+// @Router /carts/{id} [get]
+func (h *CartController) GetCartByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	cart, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cart)
+}
+
+// AddCartItem godoc
+// @Summary Tambah produk ke keranjang
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param item body models.CartItemRequest true "Item Data"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts/{id}/items [post]
+func (h *CartController) AddCartItem(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.CartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.service.AddItem(id, req)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cart)
+}
+
+// UpdateCartItem godoc
+// @Summary Ubah quantity produk di keranjang
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param product_id path int true "Product ID"
+// @Param item body models.CartItemQuantityRequest true "Quantity baru"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts/{id}/items/{product_id} [put]
+func (h *CartController) UpdateCartItem(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	productID, _ := strconv.Atoi(c.Param("product_id"))
+	var req models.CartItemQuantityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.service.UpdateItem(id, productID, req)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cart)
+}
+
+// RemoveCartItem godoc
+// @Summary Hapus produk dari keranjang
+// @Tags Carts
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param product_id path int true "Product ID"
+// @Success 200 {object} models.Cart
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts/{id}/items/{product_id} [delete]
+func (h *CartController) RemoveCartItem(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	productID, _ := strconv.Atoi(c.Param("product_id"))
+
+	cart, err := h.service.RemoveItem(id, productID)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cart)
+}
+
+// CancelCart godoc
+// @Summary Batalkan keranjang
+// @Description Reservasi stok keranjang langsung dilepas
+// @Tags Carts
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts/{id} [delete]
+func (h *CartController) CancelCart(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.Cancel(id); err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Cart cancelled successfully"})
+}
+
+// CheckoutCart godoc
+// @Summary Checkout keranjang
+// @Description Harga dihitung ulang saat checkout, lalu diproses sama seperti POST /checkout
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param Idempotency-Key header string false "Key unik dari client untuk mencegah checkout ganda saat retry"
+// @Param X-User header string false "Kasir yang melakukan checkout (default kasir pembuat keranjang)"
+// @Param checkout body models.CartCheckoutRequest true "Pembayaran dan voucher"
+// @Success 200 {object} models.Transaction
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /carts/{id}/checkout [post]
+func (h *CartController) CheckoutCart(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.CartCheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transaction, err := h.service.Checkout(id, req, c.GetHeader("X-User"), c.GetHeader("Idempotency-Key"))
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, transaction)
+}
+
+func cartErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidCart), errors.Is(err, service.ErrInvalidCheckout),
+		errors.Is(err, service.ErrInvalidVoucher), errors.Is(err, repository.ErrInsufficientPayment),
+		errors.Is(err, repository.ErrCartEmpty), errors.Is(err, repository.ErrVariantRequired),
+		errors.Is(err, repository.ErrVariantMismatch), errors.Is(err, repository.ErrUnitNotFound),
+		errors.Is(err, repository.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrCartNotFound), errors.Is(err, repository.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrCartNotOpen), errors.Is(err, repository.ErrInsufficientStock),
+		errors.Is(err, repository.ErrCartUnitMismatch),
+		errors.Is(err, repository.ErrIdempotencyKeyConflict), errors.Is(err, repository.ErrProductUnderCount):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
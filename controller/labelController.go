@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LabelController struct {
+	service *service.LabelService
+}
+
+func NewLabelController(service *service.LabelService) *LabelController {
+	return &LabelController{service: service}
+}
+
+// GetLabelLayouts godoc
+// @Summary Daftar layout lembar label preset
+// @Tags Labels
+// @Produce json
+// @Success 200 {array} models.LabelSheet
+// @Router /labels/layouts [get]
+func (h *LabelController) GetLabelLayouts(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Layouts())
+}
+
+// RenderLabels godoc
+// @Summary Cetak label rak produk ke PDF atau PNG
+// @Description Label berisi nama, harga dan barcode (EAN-13 atau Code128) produk. PDF berisi semua halaman,
+// @Description PNG hanya halaman yang dipilih; jumlah halaman ada di header X-Total-Pages.
+// @Tags Labels
+// @Accept json
+// @Produce application/pdf
+// @Produce image/png
+// @Param labels body models.LabelRequest true "Label Data"
+// @Success 200 {file} file
+// @Header 200 {integer} X-Total-Pages "Jumlah halaman"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /labels [post]
+func (h *LabelController) RenderLabels(c *gin.Context) {
+	var req models.LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := h.service.Render(req)
+	if err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := "labels.pdf"
+	if file.ContentType == "image/png" {
+		filename = "labels.png"
+	}
+	c.Header("X-Total-Pages", strconv.Itoa(file.Pages))
+	c.Header("Content-Disposition", `inline; filename="`+filename+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidLabelRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrBarcodeNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
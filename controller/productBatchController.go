@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductBatchController struct {
+	service *service.ProductBatchService
+}
+
+func NewProductBatchController(service *service.ProductBatchService) *ProductBatchController {
+	return &ProductBatchController{service: service}
+}
+
+// GetProductBatches godoc
+// @Summary Batch stok produk (urutan FEFO)
+// @Tags Batches
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param include_empty query bool false "Sertakan batch yang sudah habis"
+// @Success 200 {array} models.ProductBatch
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/batches [get]
+func (h *ProductBatchController) GetProductBatches(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	batches, err := h.service.GetByProduct(id, c.Query("include_empty") == "true")
+	if err != nil {
+		c.JSON(batchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, batches)
+}
+
+// GetExpiringBatches godoc
+// @Summary Laporan batch yang akan kadaluarsa
+// @Description Batch dengan sisa stok yang kadaluarsa dalam beberapa hari ke depan, termasuk yang sudah kadaluarsa
+// @Tags Batches
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default 30, maks 365)"
+// @Success 200 {array} models.ProductBatch
+// @Failure 400 {object} map[string]string
+// @Router /batches/expiring [get]
+func (h *ProductBatchController) GetExpiringBatches(c *gin.Context) {
+	days := 30
+	if v := c.Query("days"); v != "" {
+		var err error
+		if days, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+			return
+		}
+	}
+
+	batches, err := h.service.GetExpiring(days)
+	if err != nil {
+		c.JSON(batchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, batches)
+}
+
+// WriteOffBatch godoc
+// @Summary Hapus sisa stok batch yang sudah kadaluarsa
+// @Tags Batches
+// @Accept json
+// @Produce json
+// @Param id path int true "Batch ID"
+// @Param X-User header string false "Petugas yang melakukan write-off"
+// @Param write_off body models.BatchWriteOffRequest false "Write-off Data"
+// @Success 201 {object} models.StockMovement
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /batches/{id}/write-off [post]
+func (h *ProductBatchController) WriteOffBatch(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	req, ok := bindWriteOff(c)
+	if !ok {
+		return
+	}
+
+	movement, err := h.service.WriteOff(id, req)
+	if err != nil {
+		c.JSON(batchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
+
+// WriteOffExpiredBatches godoc
+// @Summary Hapus sisa stok semua batch yang sudah kadaluarsa
+// @Tags Batches
+// @Accept json
+// @Produce json
+// @Param X-User header string false "Petugas yang melakukan write-off"
+// @Param write_off body models.BatchWriteOffRequest false "Write-off Data"
+// @Success 201 {array} models.StockMovement
+// @Failure 500 {object} map[string]string
+// @Router /batches/write-off-expired [post]
+func (h *ProductBatchController) WriteOffExpiredBatches(c *gin.Context) {
+	req, ok := bindWriteOff(c)
+	if !ok {
+		return
+	}
+
+	movements, err := h.service.WriteOffExpired(req)
+	if err != nil {
+		c.JSON(batchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, movements)
+}
+
+// bindWriteOff membaca body opsional write-off dan petugas dari header X-User.
+func bindWriteOff(c *gin.Context) (models.BatchWriteOffRequest, bool) {
+	var req models.BatchWriteOffRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return req, false
+		}
+	}
+	req.User = c.GetHeader("X-User")
+	return req, true
+}
+
+func batchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidExpiringDays):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrBatchNotFound), errors.Is(err, repository.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrBatchNotExpired), errors.Is(err, repository.ErrBatchEmpty),
+		errors.Is(err, repository.ErrInsufficientStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"errors"
 	"kasir-api/models"
+	"kasir-api/repository"
 	"kasir-api/service"
 	"net/http"
 	"strconv"
@@ -21,6 +23,7 @@ func NewProductController(service *service.ProductService) *ProductController {
 // @Summary Ambil semua produk
 // @Tags Products
 // @Produce json
+// @Param name query string false "Cari nama (sebagian), atau SKU / barcode (persis)"
 // @Success 200 {array} models.Product
 // @Router /products [get]
 func (h *ProductController) GetAllProducts(c *gin.Context) {
@@ -35,6 +38,7 @@ func (h *ProductController) GetAllProducts(c *gin.Context) {
 
 // GetProductByID godoc
 // @Summary Ambil detail satu produk
+// @Description Untuk produk induk, response berisi options dan matriks variants
 // @Tags Products
 // @Produce json
 // @Param id path int true "Product ID"
@@ -67,7 +71,7 @@ func (h *ProductController) CreateProduct(c *gin.Context) {
 
 	err := h.service.Create(&input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -77,10 +81,12 @@ func (h *ProductController) CreateProduct(c *gin.Context) {
 
 // UpdateProduct godoc
 // @Summary Update produk
+// @Description Field stock diabaikan kecuali set_stock=true. Untuk menambah / mengurangi stok gunakan POST /products/{id}/stock-adjustments
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param set_stock query bool false "Ganti stok dengan nilai stock pada body"
 // @Param product body models.Product true "Product Data"
 // @Success 200 {object} models.Product
 // @Router /products/{id} [put]
@@ -92,9 +98,10 @@ func (h *ProductController) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	updatedProduct, err := h.service.Update(id, input)
+	setStock := c.Query("set_stock") == "true"
+	updatedProduct, err := h.service.Update(id, input, setStock)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -119,3 +126,273 @@ func (h *ProductController) DeleteProduct(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
 }
+
+// SetProductOptions godoc
+// @Summary Atur jenis opsi varian produk
+// @Description Mengganti seluruh opsi (mis. Warna, Ukuran). Varian yang sudah ada harus tetap cocok dengan opsi baru
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param options body models.ProductOptionsRequest true "Options Data"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/options [put]
+func (h *ProductController) SetProductOptions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.ProductOptionsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.service.SetOptions(id, input.Options)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// CreateProductVariant godoc
+// @Summary Tambah varian produk
+// @Description option_values harus berisi tepat satu nilai untuk setiap opsi produk induk. Induk harus berstok 0
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Parent Product ID"
+// @Param variant body models.CreateVariantRequest true "Variant Data"
+// @Success 201 {object} models.Product
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /products/{id}/variants [post]
+func (h *ProductController) CreateProductVariant(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.CreateVariantRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.service.CreateVariant(id, input)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, variant)
+}
+
+// SetProductUnits godoc
+// @Summary Atur satuan produk
+// @Description Mengganti satuan tambahan (mis. pack, dus, kg) beserta faktor konversi ke base_unit dan harga per satuan.
+// @Description Stok tetap disimpan dalam base_unit; barang timbang memakai base_unit gram dan satuan kg fractional dengan factor 1000
+// @Description price kosong atau 0 diisi harga produk × factor; sellable default true
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param units body models.ProductUnitsRequest true "Units Data"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/units [put]
+func (h *ProductController) SetProductUnits(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.ProductUnitsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.service.SetUnits(id, input.Units)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// GetProductRecipe godoc
+// @Summary Ambil resep produk komposit
+// @Tags Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} models.ProductComponent
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/recipe [get]
+func (h *ProductController) GetProductRecipe(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	components, err := h.service.GetRecipe(id)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, components)
+}
+
+// SetProductRecipe godoc
+// @Summary Atur resep / isi paket produk
+// @Description Produk menjadi komposit: penjualannya mengurangi stok komponen (quantity dalam satuan dasar komponen
+// @Description per satu satuan dasar produk) dan ketersediaannya dihitung dari stok komponen. Produk harus berstok 0
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param recipe body models.ProductRecipeRequest true "Recipe Data"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/recipe [put]
+func (h *ProductController) SetProductRecipe(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.ProductRecipeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.service.SetRecipe(id, input.Components)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// DeleteProductRecipe godoc
+// @Summary Hapus resep produk
+// @Description Produk kembali memakai stoknya sendiri
+// @Tags Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/recipe [delete]
+func (h *ProductController) DeleteProductRecipe(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.DeleteRecipe(id); err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Recipe deleted successfully"})
+}
+
+// GetProductByBarcode godoc
+// @Summary Cari produk dari hasil scan barcode
+// @Description Mencocokkan barcode produk, lalu SKU jika tidak ada barcode yang cocok
+// @Tags Products
+// @Produce json
+// @Param code path string true "Barcode / SKU"
+// @Success 200 {object} models.Product
+// @Failure 404 {object} map[string]string
+// @Router /products/barcode/{code} [get]
+func (h *ProductController) GetProductByBarcode(c *gin.Context) {
+	product, err := h.service.GetByCode(c.Param("code"))
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// AddProductBarcode godoc
+// @Summary Tambah barcode produk
+// @Description type boleh kosong: 13 digit dianggap ean13, 12 digit upc, selain itu internal. Digit cek EAN / UPC divalidasi
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param barcode body models.ProductBarcode true "Barcode Data"
+// @Success 201 {object} models.ProductBarcode
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /products/{id}/barcodes [post]
+func (h *ProductController) AddProductBarcode(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.ProductBarcode
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	barcode, err := h.service.AddBarcode(id, input)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, barcode)
+}
+
+// DeleteProductBarcode godoc
+// @Summary Hapus barcode produk
+// @Tags Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param code path string true "Barcode"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/barcodes/{code} [delete]
+func (h *ProductController) DeleteProductBarcode(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.DeleteBarcode(id, c.Param("code")); err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Barcode deleted successfully"})
+}
+
+// GenerateProductBarcode godoc
+// @Summary Buat barcode internal untuk satu produk
+// @Description EAN-13 dengan prefix 20 (khusus dalam toko). Tidak membuat apa-apa jika produk sudah punya barcode
+// @Tags Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} models.ProductBarcode
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/barcodes/generate [post]
+func (h *ProductController) GenerateProductBarcode(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	barcodes, err := h.service.GenerateBarcodes(id)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, barcodes)
+}
+
+// GenerateMissingBarcodes godoc
+// @Summary Buat barcode internal untuk semua produk tanpa barcode
+// @Description EAN-13 dengan prefix 20 (khusus dalam toko). Induk varian dilewati karena yang dijual variannya
+// @Tags Products
+// @Produce json
+// @Success 200 {array} models.ProductBarcode
+// @Router /products/barcodes/generate [post]
+func (h *ProductController) GenerateMissingBarcodes(c *gin.Context) {
+	barcodes, err := h.service.GenerateBarcodes(0)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, barcodes)
+}
+
+func productErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidProductOptions), errors.Is(err, service.ErrInvalidVariant),
+		errors.Is(err, service.ErrInvalidProductUnits), errors.Is(err, repository.ErrInvalidRecipe),
+		errors.Is(err, service.ErrInvalidBarcode):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, service.ErrProductNotComposite),
+		errors.Is(err, repository.ErrBarcodeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrBarcodeExists), errors.Is(err, repository.ErrSKUExists),
+		errors.Is(err, repository.ErrVariantExists),
+		errors.Is(err, repository.ErrVariantParent), errors.Is(err, repository.ErrNoOwnStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
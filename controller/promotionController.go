@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionController struct {
+	service *service.PromotionService
+}
+
+func NewPromotionController(service *service.PromotionService) *PromotionController {
+	return &PromotionController{service: service}
+}
+
+// CreatePromotion godoc
+// @Summary Tambah promosi baru
+// @Description Jenis promosi: percentage, fixed, buy_x_get_y, bundle, min_spend
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Param promotion body models.Promotion true "Promotion Data"
+// @Success 201 {object} models.Promotion
+// @Failure 400 {object} map[string]string
+// @Router /promotions [post]
+func (h *PromotionController) CreatePromotion(c *gin.Context) {
+	var input models.Promotion
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Create(&input); err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, input)
+}
+
+// GetAllPromotions godoc
+// @Summary Ambil semua promosi
+// @Tags Promotions
+// @Produce json
+// @Success 200 {array} models.Promotion
+// @Router /promotions [get]
+func (h *PromotionController) GetAllPromotions(c *gin.Context) {
+	promotions, err := h.service.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, promotions)
+}
+
+// GetPromotionByID godoc
+// @Summary Ambil detail satu promosi
+// @Tags Promotions
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} models.Promotion
+// @Failure 404 {object} map[string]string
+// @Router /promotions/{id} [get]
+func (h *PromotionController) GetPromotionByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	promotion, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// UpdatePromotion godoc
+// @Summary Update promosi
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Param promotion body models.Promotion true "Promotion Data"
+// @Success 200 {object} models.Promotion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /promotions/{id} [put]
+func (h *PromotionController) UpdatePromotion(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.Promotion
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedPromotion, err := h.service.Update(id, input)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPromotion)
+}
+
+// DeletePromotion godoc
+// @Summary Hapus promosi
+// @Tags Promotions
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /promotions/{id} [delete]
+func (h *PromotionController) DeletePromotion(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.Delete(id); err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Promotion deleted successfully"})
+}
+
+func promotionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidPromotion):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrPromotionNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
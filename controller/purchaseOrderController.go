@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderController struct {
+	service *service.PurchaseService
+}
+
+func NewPurchaseOrderController(service *service.PurchaseService) *PurchaseOrderController {
+	return &PurchaseOrderController{service: service}
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Buat purchase order ke supplier
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param X-User header string false "Petugas yang membuat PO"
+// @Param order body models.CreatePurchaseOrderRequest true "Purchase Order Data"
+// @Success 201 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /purchase-orders [post]
+func (h *PurchaseOrderController) CreatePurchaseOrder(c *gin.Context) {
+	var req models.CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.CreatedBy = c.GetHeader("X-User")
+
+	order, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetAllPurchaseOrders godoc
+// @Summary Daftar purchase order
+// @Tags Purchase Orders
+// @Produce json
+// @Param supplier_id query int false "Filter supplier"
+// @Param status query string false "open, partially_received, received atau cancelled"
+// @Success 200 {array} models.PurchaseOrder
+// @Failure 400 {object} map[string]string
+// @Router /purchase-orders [get]
+func (h *PurchaseOrderController) GetAllPurchaseOrders(c *gin.Context) {
+	supplierID, _ := strconv.Atoi(c.Query("supplier_id"))
+	orders, err := h.service.GetAll(supplierID, c.Query("status"))
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetPurchaseOrderByID godoc
+// @Summary Ambil purchase order beserta penerimaan barangnya
+// @Tags Purchase Orders
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {object} map[string]string
+// @Router /purchase-orders/{id} [get]
+func (h *PurchaseOrderController) GetPurchaseOrderByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	order, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary Batalkan purchase order
+// @Description Barang yang sudah diterima tetap tercatat, sisa pesanan tidak bisa diterima lagi
+// @Tags Purchase Orders
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /purchase-orders/{id}/cancel [post]
+func (h *PurchaseOrderController) CancelPurchaseOrder(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	order, err := h.service.Cancel(id)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary Terima barang dari purchase order
+// @Description Penerimaan boleh parsial. Stok bertambah dan harga pokok produk diperbarui dengan rata-rata tertimbang. Isi batch_number / expires_at untuk mencatat barang sebagai batch
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase Order ID"
+// @Param X-User header string false "Petugas yang menerima barang"
+// @Param receipt body models.GoodsReceiptRequest true "Goods Receipt Data"
+// @Success 201 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /purchase-orders/{id}/receipts [post]
+func (h *PurchaseOrderController) ReceivePurchaseOrder(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.GoodsReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ReceivedBy = c.GetHeader("X-User")
+
+	order, err := h.service.Receive(id, req)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetPurchaseReport godoc
+// @Summary Laporan pembelian per supplier
+// @Description Rekap barang yang diterima per supplier berdasarkan tanggal penerimaan
+// @Tags Reports
+// @Produce json
+// @Param start_date query string false "Tanggal awal YYYY-MM-DD (default hari ini)"
+// @Param end_date query string false "Tanggal akhir YYYY-MM-DD, inklusif (default sama dengan start_date)"
+// @Param tz query string false "Zona waktu IANA (default zona waktu toko)"
+// @Param supplier_id query int false "Filter supplier"
+// @Success 200 {object} models.PurchaseReport
+// @Failure 400 {object} map[string]string
+// @Router /report/purchases [get]
+func (h *PurchaseOrderController) GetPurchaseReport(c *gin.Context) {
+	supplierID, _ := strconv.Atoi(c.Query("supplier_id"))
+	report, err := h.service.GetPurchaseReport(c.Query("start_date"), c.Query("end_date"), c.Query("tz"), supplierID)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func purchaseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidPurchaseOrder), errors.Is(err, service.ErrInvalidFilter),
+		errors.Is(err, repository.ErrProductNotInOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrPurchaseOrderNotFound), errors.Is(err, repository.ErrSupplierNotFound),
+		errors.Is(err, repository.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrPurchaseOrderClosed), errors.Is(err, repository.ErrReceiptExceedsOrder),
+		errors.Is(err, repository.ErrNoOwnStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockController struct {
+	service *service.StockService
+}
+
+func NewStockController(service *service.StockService) *StockController {
+	return &StockController{service: service}
+}
+
+// CreateStockAdjustment godoc
+// @Summary Tambah / kurangi stok produk
+// @Description quantity relatif terhadap stok saat ini (negatif mengurangi). reason: damaged, expired, lost, found, correction, transfer_in, transfer_out, other
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param X-User header string false "Petugas yang melakukan penyesuaian"
+// @Param adjustment body models.StockAdjustmentRequest true "Adjustment Data"
+// @Success 201 {object} models.StockMovement
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /products/{id}/stock-adjustments [post]
+func (h *StockController) CreateStockAdjustment(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.User = c.GetHeader("X-User")
+
+	movement, err := h.service.Adjust(id, req)
+	if err != nil {
+		c.JSON(stockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
+
+// GetStockMovements godoc
+// @Summary Riwayat pergerakan stok produk
+// @Description Ledger stok produk (penjualan, refund, penerimaan barang, penyesuaian, opname, transfer), terbaru lebih dulu
+// @Tags Stock
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param limit query int false "Jumlah data (default 50, maks 500)"
+// @Param before_id query int false "Ambil movement dengan ID lebih kecil dari ini (halaman berikutnya)"
+// @Success 200 {array} models.StockMovement
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /products/{id}/stock-movements [get]
+func (h *StockController) GetStockMovements(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var limit, beforeID int
+	var err error
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+	if v := c.Query("before_id"); v != "" {
+		if beforeID, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid before_id"})
+			return
+		}
+	}
+
+	movements, err := h.service.GetMovements(id, limit, beforeID)
+	if err != nil {
+		c.JSON(stockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, movements)
+}
+
+// GetStockReconciliation godoc
+// @Summary Cek kecocokan ledger dengan stok produk
+// @Description Membandingkan jumlah ledger per produk dengan stok saat ini; balanced false berarti ada selisih
+// @Tags Stock
+// @Produce json
+// @Success 200 {object} models.StockReconciliation
+// @Failure 500 {object} map[string]string
+// @Router /stock/reconciliation [get]
+func (h *StockController) GetStockReconciliation(c *gin.Context) {
+	result, err := h.service.Reconcile()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetLowStockProducts godoc
+// @Summary Produk dengan stok menipis
+// @Description Produk yang stoknya sudah mencapai reorder point, beserta jumlah pesan ulang yang disarankan
+// @Tags Stock
+// @Produce json
+// @Success 200 {array} models.LowStockProduct
+// @Failure 500 {object} map[string]string
+// @Router /products/low-stock [get]
+func (h *StockController) GetLowStockProducts(c *gin.Context) {
+	products, err := h.service.GetLowStock()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, products)
+}
+
+func stockErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidStockQuery), errors.Is(err, service.ErrInvalidStockAdjustment):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrBatchNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrInsufficientStock), errors.Is(err, repository.ErrNoOwnStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockOpnameController struct {
+	service *service.StockOpnameService
+}
+
+func NewStockOpnameController(service *service.StockOpnameService) *StockOpnameController {
+	return &StockOpnameController{service: service}
+}
+
+// CreateStockOpname godoc
+// @Summary Buka sesi stock opname
+// @Description Snapshot stok sistem semua produk atau satu kategori. block_sales true menolak checkout produk dalam sesi sampai sesi ditutup; jika false, produk yang terjual selama hitung ditandai flagged
+// @Tags Stock Opname
+// @Accept json
+// @Produce json
+// @Param X-User header string false "Petugas yang membuka sesi"
+// @Param opname body models.CreateStockOpnameRequest true "Stock Opname Data"
+// @Success 201 {object} models.StockOpname
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /stock-opnames [post]
+func (h *StockOpnameController) CreateStockOpname(c *gin.Context) {
+	var req models.CreateStockOpnameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.CreatedBy = c.GetHeader("X-User")
+
+	opname, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, opname)
+}
+
+// GetAllStockOpnames godoc
+// @Summary Daftar sesi stock opname beserta ringkasan selisih
+// @Tags Stock Opname
+// @Produce json
+// @Param status query string false "counting, approved atau cancelled"
+// @Success 200 {array} models.StockOpname
+// @Failure 400 {object} map[string]string
+// @Router /stock-opnames [get]
+func (h *StockOpnameController) GetAllStockOpnames(c *gin.Context) {
+	opnames, err := h.service.GetAll(c.Query("status"))
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opnames)
+}
+
+// GetStockOpnameByID godoc
+// @Summary Ambil sesi stock opname dengan selisih per produk
+// @Tags Stock Opname
+// @Produce json
+// @Param id path int true "Stock Opname ID"
+// @Success 200 {object} models.StockOpname
+// @Failure 404 {object} map[string]string
+// @Router /stock-opnames/{id} [get]
+func (h *StockOpnameController) GetStockOpnameByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	opname, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opname)
+}
+
+// SubmitStockOpnameCounts godoc
+// @Summary Kirim hasil hitung petugas
+// @Description Hitungan ulang oleh petugas yang sama menggantikan hitungan sebelumnya; hitungan petugas berbeda dijumlahkan
+// @Tags Stock Opname
+// @Accept json
+// @Produce json
+// @Param id path int true "Stock Opname ID"
+// @Param X-User header string true "Petugas yang menghitung"
+// @Param counts body models.StockOpnameCountRequest true "Count Data"
+// @Success 200 {object} models.StockOpname
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /stock-opnames/{id}/counts [post]
+func (h *StockOpnameController) SubmitStockOpnameCounts(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.StockOpnameCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.CountedBy = c.GetHeader("X-User")
+
+	opname, err := h.service.SubmitCounts(id, req)
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opname)
+}
+
+// ApproveStockOpname godoc
+// @Summary Setujui stock opname dan posting koreksi stok
+// @Description Selisih hitung terhadap snapshot ditambahkan ke stok berjalan dalam satu transaksi. Produk yang belum dihitung tidak dikoreksi
+// @Tags Stock Opname
+// @Produce json
+// @Param id path int true "Stock Opname ID"
+// @Param X-User header string true "Petugas yang menyetujui"
+// @Success 200 {object} models.StockOpname
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /stock-opnames/{id}/approve [post]
+func (h *StockOpnameController) ApproveStockOpname(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	opname, err := h.service.Approve(id, c.GetHeader("X-User"))
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opname)
+}
+
+// CancelStockOpname godoc
+// @Summary Batalkan sesi stock opname tanpa mengubah stok
+// @Tags Stock Opname
+// @Produce json
+// @Param id path int true "Stock Opname ID"
+// @Param X-User header string false "Petugas yang membatalkan"
+// @Success 200 {object} models.StockOpname
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /stock-opnames/{id}/cancel [post]
+func (h *StockOpnameController) CancelStockOpname(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	opname, err := h.service.Cancel(id, c.GetHeader("X-User"))
+	if err != nil {
+		c.JSON(stockOpnameErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opname)
+}
+
+func stockOpnameErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidStockOpname), errors.Is(err, service.ErrUserRequired),
+		errors.Is(err, repository.ErrStockOpnameEmpty), errors.Is(err, repository.ErrProductNotInOpname):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrStockOpnameNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrStockOpnameClosed), errors.Is(err, repository.ErrStockOpnameInProgress),
+		errors.Is(err, repository.ErrInsufficientStock), errors.Is(err, repository.ErrNoOwnStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
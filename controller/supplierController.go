@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierController struct {
+	service *service.SupplierService
+}
+
+func NewSupplierController(service *service.SupplierService) *SupplierController {
+	return &SupplierController{service: service}
+}
+
+// CreateSupplier godoc
+// @Summary Tambah supplier baru
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Param supplier body models.Supplier true "Supplier Data"
+// @Success 201 {object} models.Supplier
+// @Failure 400 {object} map[string]string
+// @Router /suppliers [post]
+func (h *SupplierController) CreateSupplier(c *gin.Context) {
+	var input models.Supplier
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Create(&input); err != nil {
+		c.JSON(supplierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, input)
+}
+
+// GetAllSuppliers godoc
+// @Summary Ambil semua supplier
+// @Tags Suppliers
+// @Produce json
+// @Success 200 {array} models.Supplier
+// @Router /suppliers [get]
+func (h *SupplierController) GetAllSuppliers(c *gin.Context) {
+	suppliers, err := h.service.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, suppliers)
+}
+
+// GetSupplierByID godoc
+// @Summary Ambil supplier berdasarkan ID
+// @Tags Suppliers
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} models.Supplier
+// @Failure 404 {object} map[string]string
+// @Router /suppliers/{id} [get]
+func (h *SupplierController) GetSupplierByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	supplier, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(supplierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, supplier)
+}
+
+// UpdateSupplier godoc
+// @Summary Update supplier
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Param supplier body models.Supplier true "Supplier Data"
+// @Success 200 {object} models.Supplier
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /suppliers/{id} [put]
+func (h *SupplierController) UpdateSupplier(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.Supplier
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedSupplier, err := h.service.Update(id, input)
+	if err != nil {
+		c.JSON(supplierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updatedSupplier)
+}
+
+// DeleteSupplier godoc
+// @Summary Hapus supplier
+// @Tags Suppliers
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /suppliers/{id} [delete]
+func (h *SupplierController) DeleteSupplier(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.Delete(id); err != nil {
+		c.JSON(supplierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Supplier deleted successfully"})
+}
+
+func supplierErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidSupplier):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrSupplierNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
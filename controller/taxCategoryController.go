@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxCategoryController struct {
+	service *service.TaxCategoryService
+}
+
+func NewTaxCategoryController(service *service.TaxCategoryService) *TaxCategoryController {
+	return &TaxCategoryController{service: service}
+}
+
+// CreateTaxCategory godoc
+// @Summary Tambah kategori pajak baru
+// @Description Tarif PPN dalam persen, 0 untuk barang bebas pajak
+// @Tags Tax Categories
+// @Accept json
+// @Produce json
+// @Param tax_category body models.TaxCategory true "Tax Category Data"
+// @Success 201 {object} models.TaxCategory
+// @Failure 400 {object} map[string]string
+// @Router /tax-categories [post]
+func (h *TaxCategoryController) CreateTaxCategory(c *gin.Context) {
+	var input models.TaxCategory
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Create(&input); err != nil {
+		c.JSON(taxCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, input)
+}
+
+// GetAllTaxCategories godoc
+// @Summary Ambil semua kategori pajak
+// @Tags Tax Categories
+// @Produce json
+// @Success 200 {array} models.TaxCategory
+// @Router /tax-categories [get]
+func (h *TaxCategoryController) GetAllTaxCategories(c *gin.Context) {
+	categories, err := h.service.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, categories)
+}
+
+// GetTaxCategoryByID godoc
+// @Summary Ambil detail satu kategori pajak
+// @Tags Tax Categories
+// @Produce json
+// @Param id path int true "Tax Category ID"
+// @Success 200 {object} models.TaxCategory
+// @Failure 404 {object} map[string]string
+// @Router /tax-categories/{id} [get]
+func (h *TaxCategoryController) GetTaxCategoryByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	category, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(taxCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// UpdateTaxCategory godoc
+// @Summary Update kategori pajak
+// @Tags Tax Categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax Category ID"
+// @Param tax_category body models.TaxCategory true "Tax Category Data"
+// @Success 200 {object} models.TaxCategory
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tax-categories/{id} [put]
+func (h *TaxCategoryController) UpdateTaxCategory(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.TaxCategory
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedCategory, err := h.service.Update(id, input)
+	if err != nil {
+		c.JSON(taxCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedCategory)
+}
+
+// DeleteTaxCategory godoc
+// @Summary Hapus kategori pajak
+// @Tags Tax Categories
+// @Produce json
+// @Param id path int true "Tax Category ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tax-categories/{id} [delete]
+func (h *TaxCategoryController) DeleteTaxCategory(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.Delete(id); err != nil {
+		c.JSON(taxCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tax category deleted successfully"})
+}
+
+func taxCategoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidTaxCategory):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrTaxCategoryNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,9 +1,15 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"kasir-api/models"
+	"kasir-api/repository"
 	"kasir-api/service"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,8 +27,12 @@ func NewTransactionController(service *service.TransactionService) *TransactionC
 // @Tags Transactions
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key unik dari client untuk mencegah checkout ganda saat retry"
+// @Param X-User header string false "Kasir yang melakukan checkout"
 // @Param checkout body models.CheckoutRequest true "Checkout Data"
 // @Success 200 {object} models.Transaction
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /checkout [post]
 func (h *TransactionController) HandleCheckout(c *gin.Context) {
 	var req models.CheckoutRequest
@@ -31,7 +41,21 @@ func (h *TransactionController) HandleCheckout(c *gin.Context) {
 		return
 	}
 
-	transaction, err := h.service.Checkout(req.Items)
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	req.Cashier = c.GetHeader("X-User")
+
+	transaction, err := h.service.Checkout(req)
+	if errors.Is(err, service.ErrInvalidCheckout) || errors.Is(err, service.ErrInvalidVoucher) || errors.Is(err, repository.ErrInsufficientPayment) ||
+		errors.Is(err, repository.ErrVariantRequired) || errors.Is(err, repository.ErrVariantMismatch) ||
+		errors.Is(err, repository.ErrUnitNotFound) || errors.Is(err, repository.ErrInvalidQuantity) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrIdempotencyKeyConflict) || errors.Is(err, repository.ErrInsufficientStock) ||
+		errors.Is(err, repository.ErrProductUnderCount) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -40,8 +64,256 @@ func (h *TransactionController) HandleCheckout(c *gin.Context) {
 	c.JSON(http.StatusOK, transaction)
 }
 
+// GetAllTransactions godoc
+// @Summary Riwayat transaksi
+// @Description Cursor pagination: kirim next_cursor dari response sebelumnya sebagai parameter cursor
+// @Tags Transactions
+// @Produce json
+// @Param start_date query string false "Tanggal awal (YYYY-MM-DD di zona waktu toko, atau RFC3339), inklusif"
+// @Param end_date query string false "Tanggal akhir (YYYY-MM-DD di zona waktu toko, atau RFC3339), tanggal saja berarti sampai akhir hari itu"
+// @Param min_total query number false "Total minimal"
+// @Param max_total query number false "Total maksimal"
+// @Param product_id query int false "Hanya transaksi yang berisi produk ini"
+// @Param payment_method query string false "Hanya transaksi dengan metode pembayaran ini"
+// @Param cashier query string false "Nama kasir"
+// @Param sort query string false "created_at, -created_at (default), total_amount, -total_amount"
+// @Param cursor query string false "Cursor halaman berikutnya"
+// @Param limit query int false "Jumlah data per halaman (default 20, maks 100)"
+// @Success 200 {object} models.TransactionPage
+// @Failure 400 {object} map[string]string
+// @Router /transactions [get]
+func (h *TransactionController) GetAllTransactions(c *gin.Context) {
+	filter, err := h.parseTransactionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.GetAll(filter)
+	if errors.Is(err, service.ErrInvalidFilter) || errors.Is(err, repository.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetTransactionByID godoc
+// @Summary Ambil detail satu transaksi
+// @Tags Transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} models.Transaction
+// @Failure 404 {object} map[string]string
+// @Router /transactions/{id} [get]
+func (h *TransactionController) GetTransactionByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	transaction, err := h.service.GetByID(id)
+	if errors.Is(err, repository.ErrTransactionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionController) parseTransactionFilter(c *gin.Context) (models.TransactionFilter, error) {
+	filter := models.TransactionFilter{
+		PaymentMethod: c.Query("payment_method"),
+		Cashier:       c.Query("cashier"),
+		Sort:          c.Query("sort"),
+		Cursor:        c.Query("cursor"),
+	}
+
+	if v := c.Query("start_date"); v != "" {
+		t, _, err := parseDateParam(v, h.service.StoreLocation())
+		if err != nil {
+			return filter, fmt.Errorf("invalid start_date: %w", err)
+		}
+		filter.StartDate = &t
+	}
+	if v := c.Query("end_date"); v != "" {
+		t, dateOnly, err := parseDateParam(v, h.service.StoreLocation())
+		if err != nil {
+			return filter, fmt.Errorf("invalid end_date: %w", err)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.EndDate = &t
+	}
+
+	var err error
+	if filter.MinTotal, err = optionalMoneyQuery(c, "min_total"); err != nil {
+		return filter, err
+	}
+	if filter.MaxTotal, err = optionalMoneyQuery(c, "max_total"); err != nil {
+		return filter, err
+	}
+
+	if v := c.Query("product_id"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid product_id")
+		}
+		filter.ProductID = n
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid limit")
+		}
+		filter.Limit = n
+	}
+	return filter, nil
+}
+
+func optionalMoneyQuery(c *gin.Context, name string) (*models.Money, error) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, nil
+	}
+	major, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	m := models.MoneyFromFloat(major, models.DefaultCurrency)
+	return &m, nil
+}
+
+// parseDateParam menerima YYYY-MM-DD (di zona waktu loc) atau RFC3339. dateOnly bernilai true untuk format tanggal saja.
+func parseDateParam(v string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", v, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	return t, false, err
+}
+
+// VoidTransaction godoc
+// @Summary Void transaksi
+// @Description Membatalkan seluruh sisa item transaksi dan mengembalikan stok
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User header string true "User yang melakukan void"
+// @Param void body models.VoidRequest true "Void Data"
+// @Success 201 {object} models.Refund
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /transactions/{id}/void [post]
+func (h *TransactionController) VoidTransaction(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.VoidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.User = c.GetHeader("X-User")
+
+	refund, err := h.service.Void(id, req)
+	if err != nil {
+		c.JSON(refundErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, refund)
+}
+
+// CreateRefund godoc
+// @Summary Refund sebagian item transaksi
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param X-User header string true "User yang melakukan refund"
+// @Param refund body models.RefundRequest true "Refund Data"
+// @Success 201 {object} models.Refund
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /transactions/{id}/refunds [post]
+func (h *TransactionController) CreateRefund(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var req models.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.User = c.GetHeader("X-User")
+
+	refund, err := h.service.Refund(id, req)
+	if err != nil {
+		c.JSON(refundErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, refund)
+}
+
+func refundErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserRequired), errors.Is(err, repository.ErrInvalidRefund):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrTransactionVoided):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetReport godoc
+// @Summary Get sales report for a date range
+// @Tags Reports
+// @Produce json
+// @Param start_date query string false "Tanggal awal YYYY-MM-DD (default hari ini)"
+// @Param end_date query string false "Tanggal akhir YYYY-MM-DD, inklusif (default sama dengan start_date)"
+// @Param tz query string false "Zona waktu IANA, mis. Asia/Jakarta, Asia/Makassar, Asia/Jayapura (default zona waktu toko)"
+// @Param sections query string false "Bagian tambahan dipisah koma: top_products, categories, basket, hourly, profit, atau all"
+// @Param top query int false "Jumlah produk pada top_products (default 5, maks 50)"
+// @Success 200 {object} models.SalesReport
+// @Failure 400 {object} map[string]string
+// @Router /report [get]
+func (h *TransactionController) GetReport(c *gin.Context) {
+	var sections []string
+	if v := c.Query("sections"); v != "" {
+		for _, section := range strings.Split(v, ",") {
+			sections = append(sections, strings.TrimSpace(section))
+		}
+	}
+	topN := 0
+	if v := c.Query("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid top"})
+			return
+		}
+		topN = n
+	}
+
+	report, err := h.service.GetReport(c.Query("start_date"), c.Query("end_date"), c.Query("tz"), sections, topN)
+	if errors.Is(err, service.ErrInvalidFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
 // GetDailyReport godoc
 // @Summary Get sales report for today
+// @Description Hari ini menurut zona waktu toko (STORE_TIMEZONE)
 // @Tags Reports
 // @Produce json
 // @Success 200 {object} models.SalesReport
@@ -54,3 +326,27 @@ func (h *TransactionController) GetDailyReport(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, report)
 }
+
+// GetTaxSummary godoc
+// @Summary Get tax (PPN) and service charge summary for a date range
+// @Description Rekap DPP, PPN dan service charge per tarif untuk pelaporan pajak, sudah dikurangi refund
+// @Tags Reports
+// @Produce json
+// @Param start_date query string false "Tanggal awal YYYY-MM-DD (default hari ini)"
+// @Param end_date query string false "Tanggal akhir YYYY-MM-DD, inklusif (default sama dengan start_date)"
+// @Param tz query string false "Zona waktu IANA (default zona waktu toko)"
+// @Success 200 {object} models.TaxSummary
+// @Failure 400 {object} map[string]string
+// @Router /report/tax [get]
+func (h *TransactionController) GetTaxSummary(c *gin.Context) {
+	summary, err := h.service.GetTaxSummary(c.Query("start_date"), c.Query("end_date"), c.Query("tz"))
+	if errors.Is(err, service.ErrInvalidFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"kasir-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VoucherController struct {
+	service *service.VoucherService
+}
+
+func NewVoucherController(service *service.VoucherService) *VoucherController {
+	return &VoucherController{service: service}
+}
+
+// CreateVoucher godoc
+// @Summary Tambah voucher baru
+// @Description Jenis voucher: percentage atau fixed. max_uses 1 untuk voucher sekali pakai, 0 tanpa batas
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param voucher body models.Voucher true "Voucher Data"
+// @Success 201 {object} models.Voucher
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /vouchers [post]
+func (h *VoucherController) CreateVoucher(c *gin.Context) {
+	var input models.Voucher
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Create(&input); err != nil {
+		c.JSON(voucherErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, input)
+}
+
+// GetAllVouchers godoc
+// @Summary Ambil semua voucher
+// @Tags Vouchers
+// @Produce json
+// @Success 200 {array} models.Voucher
+// @Router /vouchers [get]
+func (h *VoucherController) GetAllVouchers(c *gin.Context) {
+	vouchers, err := h.service.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, vouchers)
+}
+
+// GetVoucherByID godoc
+// @Summary Ambil detail satu voucher
+// @Tags Vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Success 200 {object} models.Voucher
+// @Failure 404 {object} map[string]string
+// @Router /vouchers/{id} [get]
+func (h *VoucherController) GetVoucherByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	voucher, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(voucherErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, voucher)
+}
+
+// UpdateVoucher godoc
+// @Summary Update voucher
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Param voucher body models.Voucher true "Voucher Data"
+// @Success 200 {object} models.Voucher
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /vouchers/{id} [put]
+func (h *VoucherController) UpdateVoucher(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var input models.Voucher
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedVoucher, err := h.service.Update(id, input)
+	if err != nil {
+		c.JSON(voucherErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedVoucher)
+}
+
+// DeleteVoucher godoc
+// @Summary Hapus voucher
+// @Tags Vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /vouchers/{id} [delete]
+func (h *VoucherController) DeleteVoucher(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.service.Delete(id); err != nil {
+		c.JSON(voucherErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Voucher deleted successfully"})
+}
+
+func voucherErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidVoucher):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrVoucherNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrVoucherCodeExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,161 @@
+// Package label menggambar label rak (nama, harga dan barcode produk) ke lembar label dalam format PDF atau PNG.
+// Semua dibuat dengan library standar Go sehingga tetap bisa dipakai tanpa koneksi internet.
+package label
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/barcode"
+	"kasir-api/models"
+	"math"
+	"unicode/utf8"
+)
+
+var ErrInvalidSheet = errors.New("invalid label sheet")
+
+// Batas ukuran halaman agar PNG resolusi tinggi tidak menghabiskan memori
+const (
+	maxPageSize   = 600.0 // mm
+	minLabelSize  = 10.0  // mm
+	sizeTolerance = 0.01  // mm, toleransi pembulatan ukuran lembar
+)
+
+// Label adalah isi satu label yang sudah siap digambar.
+type Label struct {
+	Name  string
+	Price string
+	Code  string // Teks di bawah barcode
+	Bars  barcode.Bars
+}
+
+// Layout preset: lembar A4 stiker label yang umum dijual dan kertas roll printer thermal (satu label per halaman)
+var presets = []models.LabelSheet{
+	{Name: "a4-3x7", PageWidth: 210, PageHeight: 297, Columns: 3, Rows: 7, LabelWidth: 63.5, LabelHeight: 38.1, MarginLeft: 7.2, MarginTop: 15.15, GapX: 2.5},
+	{Name: "a4-4x10", PageWidth: 210, PageHeight: 297, Columns: 4, Rows: 10, LabelWidth: 48.5, LabelHeight: 25.4, MarginLeft: 8, MarginTop: 21.5},
+	{Name: "a4-5x13", PageWidth: 210, PageHeight: 297, Columns: 5, Rows: 13, LabelWidth: 38.1, LabelHeight: 21.2, MarginLeft: 4.7, MarginTop: 10.7, GapX: 2.5},
+	{Name: "roll-50x30", PageWidth: 50, PageHeight: 30, Columns: 1, Rows: 1, LabelWidth: 50, LabelHeight: 30},
+	{Name: "roll-58x40", PageWidth: 58, PageHeight: 40, Columns: 1, Rows: 1, LabelWidth: 58, LabelHeight: 40},
+}
+
+// DefaultLayout dipakai jika permintaan tidak menyebut layout.
+const DefaultLayout = "a4-3x7"
+
+func Layouts() []models.LabelSheet {
+	return append([]models.LabelSheet(nil), presets...)
+}
+
+func Layout(name string) (models.LabelSheet, bool) {
+	for _, s := range presets {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return models.LabelSheet{}, false
+}
+
+// ValidateSheet memastikan ukuran lembar masuk akal dan semua label muat di halaman.
+func ValidateSheet(s models.LabelSheet) error {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidSheet, msg) }
+
+	if s.PageWidth <= 0 || s.PageHeight <= 0 || s.PageWidth > maxPageSize || s.PageHeight > maxPageSize {
+		return invalid(fmt.Sprintf("page_width and page_height must be between 0 and %g mm", maxPageSize))
+	}
+	if s.Columns < 1 || s.Rows < 1 {
+		return invalid("columns and rows must be at least 1")
+	}
+	if s.LabelWidth < minLabelSize || s.LabelHeight < minLabelSize {
+		return invalid(fmt.Sprintf("label_width and label_height must be at least %g mm", minLabelSize))
+	}
+	if s.MarginLeft < 0 || s.MarginTop < 0 || s.GapX < 0 || s.GapY < 0 {
+		return invalid("margins and gaps cannot be negative")
+	}
+	if s.MarginLeft+float64(s.Columns)*s.LabelWidth+float64(s.Columns-1)*s.GapX > s.PageWidth+sizeTolerance {
+		return invalid("columns do not fit the page width")
+	}
+	if s.MarginTop+float64(s.Rows)*s.LabelHeight+float64(s.Rows-1)*s.GapY > s.PageHeight+sizeTolerance {
+		return invalid("rows do not fit the page height")
+	}
+	return nil
+}
+
+// Pages menghitung jumlah halaman untuk count label.
+func Pages(s models.LabelSheet, count int) int {
+	perPage := s.Columns * s.Rows
+	return (count + perPage - 1) / perPage
+}
+
+// canvas adalah permukaan gambar dalam milimeter dengan titik asal di kiri atas halaman.
+type canvas interface {
+	rect(x, y, w, h float64)
+	// text menulis s dengan tinggi em size; y adalah sisi atas teks.
+	text(x, y, size float64, bold bool, s string)
+	textWidth(size float64, bold bool, s string) float64
+	// bars menggambar barcode di tengah kotak w x h, lebar modul dipilih agar barcode beserta quiet zone muat.
+	bars(x, y, w, h float64, bars barcode.Bars)
+}
+
+// drawPage menggambar label ke satu halaman sesuai urutan baris demi baris.
+func drawPage(c canvas, s models.LabelSheet, labels []Label, border bool) {
+	for i, l := range labels {
+		col, row := i%s.Columns, i/s.Columns
+		x := s.MarginLeft + float64(col)*(s.LabelWidth+s.GapX)
+		y := s.MarginTop + float64(row)*(s.LabelHeight+s.GapY)
+		if border {
+			drawBorder(c, x, y, s.LabelWidth, s.LabelHeight)
+		}
+		drawLabel(c, x, y, s.LabelWidth, s.LabelHeight, l)
+	}
+}
+
+const borderWidth = 0.2 // mm
+
+func drawBorder(c canvas, x, y, w, h float64) {
+	c.rect(x, y, w, borderWidth)
+	c.rect(x, y+h-borderWidth, w, borderWidth)
+	c.rect(x, y, borderWidth, h)
+	c.rect(x+w-borderWidth, y, borderWidth, h)
+}
+
+// drawLabel menyusun isi label secara proporsional terhadap tingginya: nama, harga (tebal), barcode
+// dan kode di bawahnya. Barcode dilewati jika sisa ruang terlalu pendek untuk dipindai.
+func drawLabel(c canvas, x, y, w, h float64, l Label) {
+	pad := math.Min(w, h) * 0.06
+	left, top, width := x+pad, y+pad, w-2*pad
+
+	nameSize, priceSize, codeSize := h*0.13, h*0.2, h*0.09
+	c.text(left, top, nameSize, false, fit(c, l.Name, nameSize, false, width))
+	top += nameSize * 1.1
+	c.text(left, top, priceSize, true, fit(c, l.Price, priceSize, true, width))
+	top += priceSize * 1.1
+
+	codeTop := y + h - pad - codeSize
+	barHeight := codeTop - h*0.03 - top
+	if len(l.Bars) == 0 || barHeight < h*0.1 {
+		return
+	}
+	c.bars(left, top, width, barHeight, l.Bars)
+	code := fit(c, l.Code, codeSize, false, width)
+	c.text(left+(width-c.textWidth(codeSize, false, code))/2, codeTop, codeSize, false, code)
+}
+
+// fit memotong s dan menambahkan ".." jika lebih lebar dari maxWidth.
+func fit(c canvas, s string, size float64, bold bool, maxWidth float64) string {
+	if c.textWidth(size, bold, s) <= maxWidth {
+		return s
+	}
+	for s != "" {
+		_, n := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-n]
+		if c.textWidth(size, bold, s+"..") <= maxWidth {
+			break
+		}
+	}
+	return s + ".."
+}
+
+// pageLabels mengambil label untuk halaman page (mulai 1).
+func pageLabels(s models.LabelSheet, labels []Label, page int) []Label {
+	perPage := s.Columns * s.Rows
+	start := (page - 1) * perPage
+	return labels[start:min(start+perPage, len(labels))]
+}
@@ -0,0 +1,174 @@
+package label
+
+import (
+	"errors"
+	"kasir-api/barcode"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, s := range Layouts() {
+		if err := ValidateSheet(s); err != nil {
+			t.Errorf("preset %s: %v", s.Name, err)
+		}
+	}
+	if _, ok := Layout(DefaultLayout); !ok {
+		t.Errorf("default layout %s is not a preset", DefaultLayout)
+	}
+	if _, ok := Layout("a4-9x99"); ok {
+		t.Error("unknown layout must not be found")
+	}
+
+	// Layouts mengembalikan salinan, preset tidak boleh ikut berubah
+	Layouts()[0].Columns = 99
+	if s, _ := Layout(presets[0].Name); s.Columns == 99 {
+		t.Error("Layouts must return a copy of the presets")
+	}
+}
+
+func TestValidateSheet(t *testing.T) {
+	valid := models.LabelSheet{PageWidth: 100, PageHeight: 50, Columns: 2, Rows: 1, LabelWidth: 45, LabelHeight: 40, MarginLeft: 4, MarginTop: 5, GapX: 2}
+	tests := []struct {
+		name   string
+		modify func(s *models.LabelSheet)
+	}{
+		{"zero page width", func(s *models.LabelSheet) { s.PageWidth = 0 }},
+		{"page too large", func(s *models.LabelSheet) { s.PageHeight = maxPageSize + 1 }},
+		{"no columns", func(s *models.LabelSheet) { s.Columns = 0 }},
+		{"label too small", func(s *models.LabelSheet) { s.LabelHeight = minLabelSize - 1 }},
+		{"negative margin", func(s *models.LabelSheet) { s.MarginTop = -1 }},
+		{"negative gap", func(s *models.LabelSheet) { s.GapY = -1 }},
+		{"columns overflow", func(s *models.LabelSheet) { s.GapX = 10 }},
+		{"rows overflow", func(s *models.LabelSheet) { s.Rows = 2 }},
+	}
+
+	if err := ValidateSheet(valid); err != nil {
+		t.Fatalf("ValidateSheet(valid) = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.modify(&s)
+			if err := ValidateSheet(s); !errors.Is(err, ErrInvalidSheet) {
+				t.Errorf("ValidateSheet error = %v, want ErrInvalidSheet", err)
+			}
+		})
+	}
+}
+
+func TestPages(t *testing.T) {
+	sheet, _ := Layout("a4-3x7")
+	tests := []struct {
+		count, want, lastPage int
+	}{
+		{0, 0, 0},
+		{1, 1, 1},
+		{21, 1, 21},
+		{22, 2, 1},
+		{1000, 48, 13},
+	}
+	for _, tt := range tests {
+		got := Pages(sheet, tt.count)
+		if got != tt.want {
+			t.Errorf("Pages(%d) = %d, want %d", tt.count, got, tt.want)
+			continue
+		}
+		if got > 0 {
+			if n := len(pageLabels(sheet, make([]Label, tt.count), got)); n != tt.lastPage {
+				t.Errorf("last page of %d labels has %d labels, want %d", tt.count, n, tt.lastPage)
+			}
+		}
+	}
+}
+
+type rect struct{ x, y, w, h float64 }
+
+// recordCanvas mencatat semua yang digambar; lebar teks dianggap setengah em per karakter.
+type recordCanvas struct {
+	rects []rect
+	texts []string
+}
+
+func (c *recordCanvas) rect(x, y, w, h float64) { c.rects = append(c.rects, rect{x, y, w, h}) }
+
+func (c *recordCanvas) text(x, y, size float64, _ bool, s string) {
+	c.rects = append(c.rects, rect{x, y, c.textWidth(size, false, s), size})
+	c.texts = append(c.texts, s)
+}
+
+func (c *recordCanvas) textWidth(size float64, _ bool, s string) float64 {
+	return float64(len([]rune(s))) * size / 2
+}
+
+func (c *recordCanvas) bars(x, y, w, h float64, _ barcode.Bars) {
+	c.rects = append(c.rects, rect{x, y, w, h})
+}
+
+// Satu halaman penuh: setiap label digambar di kotaknya sendiri sesuai margin, gap dan urutan baris demi baris.
+func TestDrawPageLayout(t *testing.T) {
+	const eps = 1e-9
+	l := Label{Name: "Kopi Susu Gula Aren Ukuran Besar Sekali", Price: "Rp15.000", Code: "4006381333931", Bars: make(barcode.Bars, 95)}
+	for _, s := range Layouts() {
+		t.Run(s.Name, func(t *testing.T) {
+			labels := make([]Label, s.Columns*s.Rows)
+			for i := range labels {
+				labels[i] = l
+			}
+			c := &recordCanvas{}
+			drawPage(c, s, labels, true)
+
+			boxes := make([]rect, len(labels))
+			for i := range boxes {
+				col, row := i%s.Columns, i/s.Columns
+				boxes[i] = rect{s.MarginLeft + float64(col)*(s.LabelWidth+s.GapX), s.MarginTop + float64(row)*(s.LabelHeight+s.GapY), s.LabelWidth, s.LabelHeight}
+			}
+			inside := func(r, box rect) bool {
+				return r.x >= box.x-eps && r.y >= box.y-eps && r.x+r.w <= box.x+box.w+eps && r.y+r.h <= box.y+box.h+eps
+			}
+
+			topBorders := make([]int, len(boxes))
+			for _, r := range c.rects {
+				found := false
+				for i, box := range boxes {
+					if inside(r, box) {
+						found = true
+						if r == (rect{box.x, box.y, box.w, borderWidth}) {
+							topBorders[i]++
+						}
+						break
+					}
+				}
+				if !found {
+					t.Errorf("shape %+v is outside every label", r)
+				}
+			}
+			for i, n := range topBorders {
+				if n != 1 {
+					t.Errorf("label %d at (%g, %g) has %d top borders, want 1", i, boxes[i].x, boxes[i].y, n)
+				}
+			}
+			if want := len(labels) * 3; len(c.texts) != want {
+				t.Errorf("drew %d texts, want %d (name, price and code per label)", len(c.texts), want)
+			}
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	c := &recordCanvas{}
+	tests := []struct {
+		text     string
+		maxWidth float64
+		want     string
+	}{
+		{"Kopi", 2, "Kopi"},
+		{"Kopi Susu", 2, "Ko.."},
+		{"Kopi", 0.5, ".."},
+	}
+	for _, tt := range tests {
+		if got := fit(c, tt.text, 1, false, tt.maxWidth); got != tt.want {
+			t.Errorf("fit(%q, %g) = %q, want %q", tt.text, tt.maxWidth, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package label
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"kasir-api/barcode"
+	"kasir-api/models"
+	"strings"
+)
+
+const pointsPerMM = 72 / 25.4
+
+// Lebar karakter ASCII 32-126 font standar Helvetica dan Helvetica-Bold dalam 1/1000 em (dari AFM Adobe)
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// Ascent Helvetica dalam em, dipakai untuk mengubah sisi atas teks menjadi baseline PDF
+const helveticaAscent = 0.718
+
+// winAnsi mengubah teks ke byte WinAnsiEncoding. Karakter di luar Latin-1 diganti '?'.
+func winAnsi(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return b
+}
+
+func charWidth(ch byte, bold bool) int {
+	if ch < 32 || ch > 126 {
+		return 556
+	}
+	if bold {
+		return helveticaBoldWidths[ch-32]
+	}
+	return helveticaWidths[ch-32]
+}
+
+// pdfCanvas menulis operator gambar PDF untuk satu halaman. Satuan PDF adalah point dengan titik asal
+// di kiri bawah, jadi koordinat dikonversi dari mm dan sumbu y dibalik.
+type pdfCanvas struct {
+	content    bytes.Buffer
+	pageHeight float64 // mm
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64) {
+	fmt.Fprintf(&c.content, "%.3f %.3f %.3f %.3f re f\n",
+		x*pointsPerMM, (c.pageHeight-y-h)*pointsPerMM, w*pointsPerMM, h*pointsPerMM)
+}
+
+func (c *pdfCanvas) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	var escaped strings.Builder
+	for _, ch := range winAnsi(s) {
+		if ch == '(' || ch == ')' || ch == '\\' {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteByte(ch)
+	}
+	fmt.Fprintf(&c.content, "BT /%s %.3f Tf %.3f %.3f Td (%s) Tj ET\n", font, size*pointsPerMM,
+		x*pointsPerMM, (c.pageHeight-y-size*helveticaAscent)*pointsPerMM, escaped.String())
+}
+
+func (c *pdfCanvas) textWidth(size float64, bold bool, s string) float64 {
+	width := 0
+	for _, ch := range winAnsi(s) {
+		width += charWidth(ch, bold)
+	}
+	return float64(width) / 1000 * size
+}
+
+func (c *pdfCanvas) bars(x, y, w, h float64, bars barcode.Bars) {
+	module := w / float64(len(bars)+2*barcode.QuietZone)
+	left := x + (w-module*float64(len(bars)))/2
+	// Bar yang bersebelahan digabung menjadi satu persegi
+	for i := 0; i < len(bars); {
+		if !bars[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < len(bars) && bars[i] {
+			i++
+		}
+		c.rect(left+float64(start)*module, y, float64(i-start)*module, h)
+	}
+}
+
+// RenderPDF menggambar semua label ke dokumen PDF, satu halaman per lembar. Teks memakai font standar
+// Helvetica yang tersedia di semua pembaca PDF sehingga tidak perlu menyematkan file font.
+func RenderPDF(s models.LabelSheet, labels []Label, border bool) ([]byte, error) {
+	pages := Pages(s, len(labels))
+
+	var buf bytes.Buffer
+	offsets := []int{0} // Objek 0 tidak dipakai
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets)-1, body)
+	}
+
+	// Objek 1-4: katalog, daftar halaman dan dua font; setiap halaman memakai dua objek (page dan content)
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	kids := make([]string, pages)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for page := 1; page <= pages; page++ {
+		c := &pdfCanvas{pageHeight: s.PageHeight}
+		drawPage(c, s, pageLabels(s, labels, page), border)
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(c.content.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.3f %.3f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			s.PageWidth*pointsPerMM, s.PageHeight*pointsPerMM, len(offsets)+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets))
+	for _, offset := range offsets[1:] {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets), xref)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package label
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"kasir-api/barcode"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func testLabels(t *testing.T, n int) []Label {
+	t.Helper()
+	bars, err := barcode.EncodeEAN13("4006381333931")
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := make([]Label, n)
+	for i := range labels {
+		labels[i] = Label{Name: "Teh (Botol) Manis", Price: "Rp15.000", Code: "4006381333931", Bars: bars}
+	}
+	return labels
+}
+
+var (
+	pdfStartXref = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	pdfStream    = regexp.MustCompile(`(?s)<< /Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+)
+
+// Struktur PDF diperiksa langsung: offset xref menunjuk ke setiap objek, jumlah halaman, panjang stream
+// dan isi content stream setelah didekompresi.
+func TestRenderPDFStructure(t *testing.T) {
+	sheet, _ := Layout("a4-3x7")
+	labels := testLabels(t, 25)
+	data, err := RenderPDF(sheet, labels, true)
+	if err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", data[:min(len(data), 16)])
+	}
+	m := pdfStartXref.FindSubmatch(data)
+	if m == nil {
+		t.Fatal("missing startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to the xref table", xref)
+	}
+
+	// 4 objek tetap ditambah page dan content untuk 2 halaman
+	const objects = 4 + 2*2
+	lines := strings.Split(string(data[xref:]), "\n")
+	if lines[1] != fmt.Sprintf("0 %d", objects+1) {
+		t.Fatalf("xref subsection %q, want 0 %d", lines[1], objects+1)
+	}
+	for n := 1; n <= objects; n++ {
+		offset, err := strconv.Atoi(strings.Fields(lines[2+n])[0])
+		if err != nil {
+			t.Fatalf("xref entry %d: %v", n, err)
+		}
+		if want := fmt.Sprintf("%d 0 obj\n", n); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q, want %q", n, data[offset:min(len(data), offset+10)], want)
+		}
+	}
+	if !bytes.Contains(data, []byte(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>", objects+1))) {
+		t.Error("trailer size does not match the object count")
+	}
+	if !bytes.Contains(data, []byte("/Kids [5 0 R 7 0 R] /Count 2")) {
+		t.Error("pages object must list both pages")
+	}
+
+	streams := pdfStream.FindAllSubmatchIndex(data, -1)
+	if len(streams) != 2 {
+		t.Fatalf("found %d content streams, want 2", len(streams))
+	}
+	var contents []string
+	for _, s := range streams {
+		length, _ := strconv.Atoi(string(data[s[2]:s[3]]))
+		body := data[s[1]:]
+		if !bytes.HasPrefix(body[length:], []byte("\nendstream")) {
+			t.Fatalf("stream /Length %d does not end at endstream", length)
+		}
+		r, err := zlib.NewReader(bytes.NewReader(body[:length]))
+		if err != nil {
+			t.Fatalf("decompress stream: %v", err)
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("decompress stream: %v", err)
+		}
+		contents = append(contents, string(content))
+	}
+
+	// Halaman pertama penuh (21 label), halaman kedua berisi sisa 4 label
+	for page, want := range []int{21, 4} {
+		content := contents[page]
+		for _, text := range []string{`(Teh \(Botol\) Manis) Tj`, `/F2 `, `(Rp15.000) Tj`, `(4006381333931) Tj`} {
+			if n := strings.Count(content, text); n != want {
+				t.Errorf("page %d: %q appears %d times, want %d", page+1, text, n, want)
+			}
+		}
+		// Garis tepi 4 persegi per label, barcode 4006381333931 terdiri dari 30 bar
+		if n := strings.Count(content, " re f\n"); n != want*(4+30) {
+			t.Errorf("page %d: %d rectangles, want %d", page+1, n, want*(4+30))
+		}
+	}
+}
+
+func TestRenderPDFEmpty(t *testing.T) {
+	sheet, _ := Layout("roll-50x30")
+	data, err := RenderPDF(sheet, nil, false)
+	if err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+	if !bytes.Contains(data, []byte("/Kids [] /Count 0")) {
+		t.Error("empty document must have no pages")
+	}
+}
@@ -0,0 +1,121 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"kasir-api/barcode"
+	"kasir-api/models"
+	"math"
+)
+
+// rasterCanvas menggambar ke image.Gray. Posisi dibulatkan ke piksel dan lebar modul barcode selalu
+// kelipatan piksel utuh agar barcode tetap terbaca di printer thermal.
+type rasterCanvas struct {
+	img   *image.Gray
+	scale float64 // Piksel per mm
+}
+
+// MaxPixels membatasi lebar × tinggi satu halaman PNG (1 byte per piksel) agar kombinasi halaman besar
+// dan dpi tinggi tidak menghabiskan memori.
+const MaxPixels = 40_000_000
+
+// PixelSize menghitung ukuran halaman PNG dalam piksel untuk resolusi dpi.
+func PixelSize(s models.LabelSheet, dpi int) (width, height int) {
+	scale := float64(dpi) / 25.4
+	return int(math.Round(s.PageWidth * scale)), int(math.Round(s.PageHeight * scale))
+}
+
+func newRasterCanvas(s models.LabelSheet, dpi int) *rasterCanvas {
+	width, height := PixelSize(s, dpi)
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return &rasterCanvas{img: img, scale: float64(dpi) / 25.4}
+}
+
+func (c *rasterCanvas) px(mm float64) int {
+	return int(math.Round(mm * c.scale))
+}
+
+func (c *rasterCanvas) fill(x0, y0, x1, y1 int) {
+	r := image.Rect(x0, y0, x1, y1).Intersect(c.img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.img.SetGray(x, y, color.Gray{})
+		}
+	}
+}
+
+func (c *rasterCanvas) rect(x, y, w, h float64) {
+	x0, y0 := c.px(x), c.px(y)
+	c.fill(x0, y0, x0+max(c.px(w), 1), y0+max(c.px(h), 1))
+}
+
+// fontScale adalah ukuran satu titik font bitmap dalam piksel untuk tinggi em size.
+func (c *rasterCanvas) fontScale(size float64) int {
+	return max(int(math.Round(size*c.scale/glyphEm)), 1)
+}
+
+func (c *rasterCanvas) text(x, y, size float64, bold bool, s string) {
+	scale := c.fontScale(size)
+	// Huruf tebal dibuat dengan menggambar ulang glyph bergeser ke kanan
+	weight := 0
+	if bold {
+		weight = max(scale/2, 1)
+	}
+	px, py := c.px(x), c.px(y)
+	for _, r := range s {
+		g := glyph(r)
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if g[row][col] != '#' {
+					continue
+				}
+				gx, gy := px+col*scale, py+row*scale
+				c.fill(gx, gy, gx+scale+weight, gy+scale)
+			}
+		}
+		px += glyphAdvance * scale
+	}
+}
+
+func (c *rasterCanvas) textWidth(size float64, _ bool, s string) float64 {
+	n := len([]rune(s))
+	return float64(n*glyphAdvance*c.fontScale(size)) / c.scale
+}
+
+func (c *rasterCanvas) bars(x, y, w, h float64, bars barcode.Bars) {
+	width := c.px(w)
+	module := max(width/(len(bars)+2*barcode.QuietZone), 1)
+	left := c.px(x) + (width-module*len(bars))/2
+	top, bottom := c.px(y), c.px(y+h)
+	for i, bar := range bars {
+		if bar {
+			c.fill(left+i*module, top, left+(i+1)*module, bottom)
+		}
+	}
+}
+
+// RenderPNG menggambar satu halaman (mulai 1) lembar label sebagai PNG grayscale dengan resolusi dpi.
+func RenderPNG(s models.LabelSheet, labels []Label, dpi, page int, border bool) ([]byte, error) {
+	if pages := Pages(s, len(labels)); page < 1 || page > pages {
+		return nil, fmt.Errorf("%w: page must be between 1 and %d", ErrInvalidSheet, pages)
+	}
+	if width, height := PixelSize(s, dpi); width < 1 || height < 1 || width*height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixel page must be between 1 and %d pixels, lower the dpi or page size",
+			ErrInvalidSheet, width, height, MaxPixels)
+	}
+
+	c := newRasterCanvas(s, dpi)
+	drawPage(c, s, pageLabels(s, labels, page), border)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,82 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestRenderPNG(t *testing.T) {
+	tests := []struct {
+		layout        string
+		dpi           int
+		width, height int
+	}{
+		{"roll-50x30", 203, 400, 240},
+		{"roll-58x40", 300, 685, 472},
+		{"a4-3x7", 72, 595, 842},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			sheet, _ := Layout(tt.layout)
+			data, err := RenderPNG(sheet, testLabels(t, 1), tt.dpi, 1, false)
+			if err != nil {
+				t.Fatalf("RenderPNG: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode PNG: %v", err)
+			}
+			if got := img.Bounds(); got != image.Rect(0, 0, tt.width, tt.height) {
+				t.Errorf("PNG bounds = %v, want %dx%d", got, tt.width, tt.height)
+			}
+
+			gray, ok := img.(*image.Gray)
+			if !ok {
+				t.Fatalf("PNG is %T, want grayscale", img)
+			}
+			black := 0
+			for _, p := range gray.Pix {
+				if p == 0 {
+					black++
+				}
+			}
+			if black == 0 {
+				t.Error("PNG has no black pixels")
+			}
+		})
+	}
+}
+
+func TestRenderPNGPageRange(t *testing.T) {
+	sheet, _ := Layout("a4-3x7")
+	labels := testLabels(t, 22)
+	for _, page := range []int{0, 3} {
+		if _, err := RenderPNG(sheet, labels, 72, page, false); !errors.Is(err, ErrInvalidSheet) {
+			t.Errorf("RenderPNG page %d error = %v, want ErrInvalidSheet", page, err)
+		}
+	}
+	if _, err := RenderPNG(sheet, labels, 72, 2, false); err != nil {
+		t.Errorf("RenderPNG page 2: %v", err)
+	}
+}
+
+// Halaman maksimum dengan dpi maksimum harus ditolak sebelum gambar dialokasikan.
+func TestRenderPNGPixelLimit(t *testing.T) {
+	sheet := models.LabelSheet{PageWidth: maxPageSize, PageHeight: maxPageSize, Columns: 1, Rows: 1, LabelWidth: 50, LabelHeight: 30}
+	if err := ValidateSheet(sheet); err != nil {
+		t.Fatalf("ValidateSheet: %v", err)
+	}
+	if _, err := RenderPNG(sheet, testLabels(t, 1), 600, 1, false); !errors.Is(err, ErrInvalidSheet) {
+		t.Errorf("RenderPNG error = %v, want ErrInvalidSheet", err)
+	}
+
+	// A4 pada 600 dpi masih di bawah batas
+	a4, _ := Layout("a4-3x7")
+	if width, height := PixelSize(a4, 600); width*height > MaxPixels {
+		t.Errorf("A4 at 600 dpi is %dx%d pixels, must fit MaxPixels", width, height)
+	}
+}
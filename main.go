@@ -4,11 +4,13 @@ import (
 	"kasir-api/config"
 	"kasir-api/controller"
 	"kasir-api/docs"
+	"kasir-api/migrations"
 	"kasir-api/repository"
 	"kasir-api/routes"
 	"kasir-api/service"
 	"log"
 	"os"
+	"time"
 
 	_ "kasir-api/docs"
 
@@ -41,6 +43,10 @@ func main() {
 
 	defer config.DB.Close()
 
+	if err := migrations.Run(config.DB); err != nil {
+		log.Fatalf("Failed to run database migrations: %v", err)
+	}
+
 	// --- Category Layer ---
 	categoryRepo := repository.NewCategoryRepository(config.DB)
 	categoryService := service.NewCategoryService(categoryRepo)
@@ -51,12 +57,67 @@ func main() {
 	productService := service.NewProductService(productRepo)
 	productCtrl := controller.NewProductController(productService)
 
+	// --- Stock Layer ---
+	stockMovementRepo := repository.NewStockMovementRepository(config.DB)
+	stockService := service.NewStockService(stockMovementRepo, productRepo)
+	stockCtrl := controller.NewStockController(stockService)
+
+	stockAlertConfig := config.StockAlertConfig()
+	stockAlertRepo := repository.NewStockAlertRepository(config.DB)
+	stockAlertService := service.NewStockAlertService(stockAlertRepo, service.NewStockAlertNotifier(stockAlertConfig))
+	stockAlertService.StartLowStockChecker(stockAlertConfig.Interval)
+
+	// --- Batch Layer ---
+	batchRepo := repository.NewProductBatchRepository(config.DB)
+	batchService := service.NewProductBatchService(batchRepo, productRepo, config.StoreLocation())
+	batchCtrl := controller.NewProductBatchController(batchService)
+
+	// --- Label Layer ---
+	labelService := service.NewLabelService(productRepo)
+	labelCtrl := controller.NewLabelController(labelService)
+
+	// --- Stock Opname Layer ---
+	stockOpnameRepo := repository.NewStockOpnameRepository(config.DB)
+	stockOpnameService := service.NewStockOpnameService(stockOpnameRepo)
+	stockOpnameCtrl := controller.NewStockOpnameController(stockOpnameService)
+
+	// --- Promotion Layer ---
+	promotionRepo := repository.NewPromotionRepository(config.DB)
+	promotionService := service.NewPromotionService(promotionRepo)
+	promotionCtrl := controller.NewPromotionController(promotionService)
+
+	// --- Tax Category Layer ---
+	taxCategoryRepo := repository.NewTaxCategoryRepository(config.DB)
+	taxCategoryService := service.NewTaxCategoryService(taxCategoryRepo)
+	taxCategoryCtrl := controller.NewTaxCategoryController(taxCategoryService)
+
+	// --- Voucher Layer ---
+	voucherRepo := repository.NewVoucherRepository(config.DB)
+	voucherService := service.NewVoucherService(voucherRepo)
+	voucherCtrl := controller.NewVoucherController(voucherService)
+
+	// --- Supplier Layer ---
+	supplierRepo := repository.NewSupplierRepository(config.DB)
+	supplierService := service.NewSupplierService(supplierRepo)
+	supplierCtrl := controller.NewSupplierController(supplierService)
+
+	// --- Purchase Order Layer ---
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(config.DB)
+	purchaseService := service.NewPurchaseService(purchaseOrderRepo, config.StoreLocation())
+	purchaseOrderCtrl := controller.NewPurchaseOrderController(purchaseService)
+
 	// --- Transaction Layer ---
 	transactionRepo := repository.NewTransactionRepository(config.DB)
-	transactionService := service.NewTransactionService(transactionRepo)
+	transactionService := service.NewTransactionService(transactionRepo, promotionRepo, config.StoreLocation(), config.TaxConfig())
 	transactionCtrl := controller.NewTransactionController(transactionService)
 
-	r := routes.SetupRouter(productCtrl, categoryCtrl, transactionCtrl)
+	// --- Cart Layer ---
+	cartRepo := repository.NewCartRepository(config.DB)
+	cartService := service.NewCartService(cartRepo, transactionService, config.CartTTL())
+	cartService.StartExpiryWorker(time.Minute)
+	cartCtrl := controller.NewCartController(cartService)
+
+	r := routes.SetupRouter(productCtrl, categoryCtrl, transactionCtrl, promotionCtrl, taxCategoryCtrl, voucherCtrl, cartCtrl, stockCtrl, supplierCtrl, purchaseOrderCtrl, stockOpnameCtrl, batchCtrl, labelCtrl)
 
 	// 4. Run Server
 	port := os.Getenv("PORT")
@@ -0,0 +1,7 @@
+-- Idempotency-Key dari client untuk POST /checkout.
+CREATE TABLE IF NOT EXISTS checkout_idempotency_keys (
+    idempotency_key TEXT PRIMARY KEY,
+    request_hash    TEXT NOT NULL,
+    transaction_id  INTEGER REFERENCES transactions (id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+-- Pengaman terakhir agar stok tidak pernah minus. NOT VALID supaya data lama tidak memblokir migrasi.
+ALTER TABLE products ADD CONSTRAINT products_stock_non_negative CHECK (stock >= 0) NOT VALID;
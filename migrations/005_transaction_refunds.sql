@@ -0,0 +1,23 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'completed';
+
+CREATE TABLE IF NOT EXISTS transaction_refunds (
+    id             SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions (id),
+    type           TEXT NOT NULL,
+    reason         TEXT NOT NULL,
+    user_name      TEXT NOT NULL,
+    total_amount   INTEGER NOT NULL CHECK (total_amount <= 0),
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS transaction_refund_items (
+    id                    SERIAL PRIMARY KEY,
+    refund_id             INTEGER NOT NULL REFERENCES transaction_refunds (id) ON DELETE CASCADE,
+    transaction_detail_id INTEGER NOT NULL REFERENCES transaction_details (id),
+    product_id            INTEGER NOT NULL REFERENCES products (id),
+    quantity              INTEGER NOT NULL CHECK (quantity > 0),
+    amount                INTEGER NOT NULL CHECK (amount <= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_refunds_transaction_id ON transaction_refunds (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_refund_items_detail_id ON transaction_refund_items (transaction_detail_id);
@@ -0,0 +1,4 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS cashier TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_transaction_details_transaction_id ON transaction_details (transaction_id);
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_price INTEGER NOT NULL DEFAULT 0;
+
+-- Harga pokok disalin ke detail saat checkout agar perubahan cost_price tidak mengubah laba historis
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_cost INTEGER NOT NULL DEFAULT 0;
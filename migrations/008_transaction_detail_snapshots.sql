@@ -0,0 +1,18 @@
+-- SKU produk (boleh kosong sampai diisi)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku TEXT;
+
+-- Snapshot data produk saat transaksi, supaya rename / ganti harga tidak mengubah riwayat
+ALTER TABLE transaction_details
+    ADD COLUMN IF NOT EXISTS product_name    TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS sku             TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS unit_price      INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount      INTEGER NOT NULL DEFAULT 0;
+
+-- Isi baris lama dari data produk yang ada sekarang (pendekatan terbaik yang tersedia)
+UPDATE transaction_details td
+SET product_name = p.name,
+    sku          = COALESCE(p.sku, ''),
+    unit_price   = CASE WHEN td.quantity > 0 THEN td.subtotal / td.quantity ELSE 0 END
+FROM products p
+WHERE td.product_id = p.id AND td.product_name = '';
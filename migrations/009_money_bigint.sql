@@ -0,0 +1,26 @@
+-- Semua nominal disimpan sebagai BIGINT dalam minor unit mata uang (Rupiah: 1 rupiah).
+-- Harga produk yang sebelumnya pecahan dibulatkan ke rupiah terdekat.
+ALTER TABLE products
+    ALTER COLUMN price      TYPE BIGINT USING ROUND(price)::BIGINT,
+    ALTER COLUMN cost_price TYPE BIGINT USING ROUND(cost_price)::BIGINT;
+
+ALTER TABLE transactions
+    ALTER COLUMN total_amount  TYPE BIGINT,
+    ALTER COLUMN paid_amount   TYPE BIGINT,
+    ALTER COLUMN change_amount TYPE BIGINT,
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE transaction_details
+    ALTER COLUMN unit_price      TYPE BIGINT,
+    ALTER COLUMN discount_amount TYPE BIGINT,
+    ALTER COLUMN tax_amount      TYPE BIGINT,
+    ALTER COLUMN subtotal        TYPE BIGINT,
+    ALTER COLUMN unit_cost       TYPE BIGINT;
+
+ALTER TABLE transaction_payments
+    ALTER COLUMN amount   TYPE BIGINT,
+    ALTER COLUMN tendered TYPE BIGINT,
+    ALTER COLUMN change   TYPE BIGINT;
+
+ALTER TABLE transaction_refunds ALTER COLUMN total_amount TYPE BIGINT;
+ALTER TABLE transaction_refund_items ALTER COLUMN amount TYPE BIGINT;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id               SERIAL PRIMARY KEY,
+    name             TEXT NOT NULL,
+    type             TEXT NOT NULL,
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    product_id       INTEGER REFERENCES products (id),
+    category_id      INTEGER REFERENCES categories (id),
+    discount_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    discount_amount  BIGINT NOT NULL DEFAULT 0,
+    buy_quantity     INTEGER NOT NULL DEFAULT 0,
+    get_quantity     INTEGER NOT NULL DEFAULT 0,
+    bundle_items     JSONB NOT NULL DEFAULT '[]',
+    bundle_price     BIGINT NOT NULL DEFAULT 0,
+    min_spend        BIGINT NOT NULL DEFAULT 0,
+    starts_at        TIMESTAMPTZ,
+    ends_at          TIMESTAMPTZ,
+    start_time       TEXT NOT NULL DEFAULT '',
+    end_time         TEXT NOT NULL DEFAULT '',
+    days_of_week     JSONB NOT NULL DEFAULT '[]',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at       TIMESTAMPTZ
+);
+
+-- Promosi yang dipakai per baris transaksi
+CREATE TABLE IF NOT EXISTS transaction_detail_promotions (
+    id                    SERIAL PRIMARY KEY,
+    transaction_detail_id INTEGER NOT NULL REFERENCES transaction_details (id) ON DELETE CASCADE,
+    promotion_id          INTEGER NOT NULL REFERENCES promotions (id),
+    promotion_name        TEXT NOT NULL,
+    amount                BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_promotions_detail_id ON transaction_detail_promotions (transaction_detail_id);
+
+-- Kategori produk saat transaksi, dipakai promosi per kategori dan laporan per kategori
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS category_id INTEGER;
+UPDATE transaction_details td SET category_id = p.category_id FROM products p WHERE td.product_id = p.id AND td.category_id IS NULL;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS tax_categories (
+    id          SERIAL PRIMARY KEY,
+    name        TEXT NOT NULL,
+    rate        NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at  TIMESTAMPTZ
+);
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS tax_category_id INTEGER REFERENCES tax_categories (id),
+    ADD COLUMN IF NOT EXISTS tax_exempt      BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- total_amount tetap menjadi grand total (subtotal + service charge + pajak)
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS subtotal           BIGINT,
+    ADD COLUMN IF NOT EXISTS service_charge     BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount         BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE transactions SET subtotal = total_amount WHERE subtotal IS NULL;
+ALTER TABLE transactions ALTER COLUMN subtotal SET NOT NULL, ALTER COLUMN subtotal SET DEFAULT 0;
+
+ALTER TABLE transaction_details
+    ADD COLUMN IF NOT EXISTS tax_category_id INTEGER,
+    ADD COLUMN IF NOT EXISTS tax_exempt      BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS tax_rate        NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS service_charge  BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS taxable_amount  BIGINT,
+    ADD COLUMN IF NOT EXISTS total           BIGINT;
+UPDATE transaction_details SET taxable_amount = subtotal, total = subtotal WHERE total IS NULL;
+ALTER TABLE transaction_details
+    ALTER COLUMN taxable_amount SET NOT NULL, ALTER COLUMN taxable_amount SET DEFAULT 0,
+    ALTER COLUMN total SET NOT NULL, ALTER COLUMN total SET DEFAULT 0;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS vouchers (
+    id                    SERIAL PRIMARY KEY,
+    code                  TEXT NOT NULL,
+    description           TEXT NOT NULL DEFAULT '',
+    type                  TEXT NOT NULL,
+    discount_percent      NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    discount_amount       BIGINT NOT NULL DEFAULT 0,
+    max_discount          BIGINT NOT NULL DEFAULT 0,
+    min_purchase          BIGINT NOT NULL DEFAULT 0,
+    max_uses              INTEGER NOT NULL DEFAULT 0,
+    max_uses_per_customer INTEGER NOT NULL DEFAULT 0,
+    used_count            INTEGER NOT NULL DEFAULT 0,
+    active                BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at             TIMESTAMPTZ,
+    expires_at            TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at            TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vouchers_code ON vouchers (code) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS voucher_redemptions (
+    id             SERIAL PRIMARY KEY,
+    voucher_id     INTEGER NOT NULL REFERENCES vouchers (id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions (id),
+    customer       TEXT NOT NULL DEFAULT '',
+    amount         BIGINT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_voucher_redemptions_voucher_customer ON voucher_redemptions (voucher_id, customer);
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS customer         TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS voucher_code     TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS voucher_discount BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS voucher_discount BIGINT NOT NULL DEFAULT 0;
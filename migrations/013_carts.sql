@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id             SERIAL PRIMARY KEY,
+    status         TEXT NOT NULL DEFAULT 'open',
+    customer       TEXT NOT NULL DEFAULT '',
+    cashier        TEXT NOT NULL DEFAULT '',
+    note           TEXT NOT NULL DEFAULT '',
+    reserve_stock  BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at     TIMESTAMPTZ NOT NULL,
+    transaction_id INTEGER REFERENCES transactions (id),
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_carts_status_expires_at ON carts (status, expires_at);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    cart_id    INTEGER NOT NULL REFERENCES carts (id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products (id),
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (cart_id, product_id)
+);
+
+-- Keranjang dengan reserve_stock yang masih open dan belum kadaluarsa mengurangi stok tersedia
+-- untuk checkout lain. Stok produk sendiri tidak diubah sampai keranjang di-checkout.
+CREATE INDEX IF NOT EXISTS idx_cart_items_product_id ON cart_items (product_id);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id           BIGSERIAL PRIMARY KEY,
+    product_id   INTEGER NOT NULL REFERENCES products (id),
+    type         TEXT NOT NULL,
+    quantity     INTEGER NOT NULL,
+    stock_after  INTEGER NOT NULL,
+    reference_id INTEGER,
+    note         TEXT NOT NULL DEFAULT '',
+    user_name    TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id ON stock_movements (product_id, id);
+
+-- Ledger append-only: baris yang sudah tercatat tidak boleh diubah atau dihapus
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS stock_movements_append_only ON stock_movements;
+CREATE TRIGGER stock_movements_append_only
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- Saldo awal ledger = stok saat ini
+INSERT INTO stock_movements (product_id, type, quantity, stock_after, note)
+SELECT id, 'opening', stock, stock, 'saldo awal ledger'
+FROM products
+WHERE NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = products.id);
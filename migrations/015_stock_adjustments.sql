@@ -0,0 +1,2 @@
+-- Kode alasan untuk penyesuaian stok manual
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,25 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_point INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE products ADD CONSTRAINT products_reorder_non_negative CHECK (reorder_point >= 0 AND reorder_quantity >= 0);
+
+-- Satu alert per penjualan yang membuat stok turun melewati reorder point
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    id                SERIAL PRIMARY KEY,
+    product_id        INTEGER NOT NULL REFERENCES products (id),
+    stock_movement_id BIGINT NOT NULL UNIQUE REFERENCES stock_movements (id),
+    stock             INTEGER NOT NULL,
+    reorder_point     INTEGER NOT NULL,
+    reorder_quantity  INTEGER NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notified_at       TIMESTAMPTZ,
+    -- Pengiriman yang gagal dicoba ulang dengan jeda bertambah; setelah batas percobaan alert ditandai failed_at
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    last_error        TEXT NOT NULL DEFAULT '',
+    next_attempt_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    failed_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_low_stock_alerts_pending ON low_stock_alerts (next_attempt_at, id) WHERE notified_at IS NULL AND failed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_sale_created_at ON stock_movements (created_at) WHERE type = 'sale';
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id         SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    phone      TEXT NOT NULL DEFAULT '',
+    email      TEXT NOT NULL DEFAULT '',
+    address    TEXT NOT NULL DEFAULT '',
+    note       TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id            SERIAL PRIMARY KEY,
+    supplier_id   INTEGER NOT NULL REFERENCES suppliers (id),
+    status        TEXT NOT NULL DEFAULT 'open',
+    expected_date DATE,
+    note          TEXT NOT NULL DEFAULT '',
+    created_by    TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    product_id        INTEGER NOT NULL REFERENCES products (id),
+    quantity          INTEGER NOT NULL CHECK (quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_cost         BIGINT NOT NULL,
+    UNIQUE (purchase_order_id, product_id)
+);
+
+-- Penerimaan barang; satu PO bisa diterima beberapa kali (parsial)
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders (id),
+    note              TEXT NOT NULL DEFAULT '',
+    received_by       TEXT NOT NULL DEFAULT '',
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_received_at ON goods_receipts (received_at);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    id                     SERIAL PRIMARY KEY,
+    goods_receipt_id       INTEGER NOT NULL REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    purchase_order_line_id INTEGER NOT NULL REFERENCES purchase_order_lines (id),
+    product_id             INTEGER NOT NULL REFERENCES products (id),
+    quantity               INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost              BIGINT NOT NULL
+);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS stock_opnames (
+    id                   SERIAL PRIMARY KEY,
+    status               TEXT NOT NULL DEFAULT 'counting',
+    category_id          INTEGER REFERENCES categories (id),
+    block_sales          BOOLEAN NOT NULL DEFAULT FALSE,
+    note                 TEXT NOT NULL DEFAULT '',
+    created_by           TEXT NOT NULL DEFAULT '',
+    closed_by            TEXT NOT NULL DEFAULT '',
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at            TIMESTAMPTZ
+);
+
+-- Snapshot stok sistem per produk saat sesi dibuka
+CREATE TABLE IF NOT EXISTS stock_opname_items (
+    id             SERIAL PRIMARY KEY,
+    opname_id      INTEGER NOT NULL REFERENCES stock_opnames (id) ON DELETE CASCADE,
+    product_id     INTEGER NOT NULL REFERENCES products (id),
+    expected_stock INTEGER NOT NULL,
+    cost_price     BIGINT NOT NULL,
+    UNIQUE (opname_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_opname_items_product_id ON stock_opname_items (product_id);
+
+-- Hasil hitung per petugas; hitungan ulang oleh petugas yang sama menggantikan hitungan sebelumnya
+CREATE TABLE IF NOT EXISTS stock_opname_counts (
+    id         SERIAL PRIMARY KEY,
+    opname_id  INTEGER NOT NULL REFERENCES stock_opnames (id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products (id),
+    counted_by TEXT NOT NULL DEFAULT '',
+    quantity   INTEGER NOT NULL CHECK (quantity >= 0),
+    -- Stok sistem saat hitungan disimpan; koreksi dihitung terhadap angka ini, bukan snapshot awal
+    system_stock INTEGER NOT NULL,
+    counted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (opname_id, product_id, counted_by)
+);
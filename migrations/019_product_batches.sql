@@ -0,0 +1,25 @@
+-- Stok per batch / lot dengan tanggal kadaluarsa. products.stock tetap total; stok di luar batch
+-- (stok lama, refund) adalah products.stock dikurangi jumlah batch.
+CREATE TABLE IF NOT EXISTS product_batches (
+    id               SERIAL PRIMARY KEY,
+    product_id       INTEGER NOT NULL REFERENCES products (id),
+    batch_number     TEXT NOT NULL DEFAULT '',
+    expires_at       DATE,
+    quantity         INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    initial_quantity INTEGER NOT NULL DEFAULT 0,
+    goods_receipt_id INTEGER REFERENCES goods_receipts (id),
+    received_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_batches_fefo ON product_batches (product_id, expires_at, id) WHERE quantity > 0;
+CREATE INDEX IF NOT EXISTS idx_product_batches_expires_at ON product_batches (expires_at) WHERE quantity > 0;
+
+-- Batch yang terpengaruh oleh setiap movement
+CREATE TABLE IF NOT EXISTS stock_movement_batches (
+    stock_movement_id BIGINT NOT NULL REFERENCES stock_movements (id),
+    batch_id          INTEGER NOT NULL REFERENCES product_batches (id),
+    quantity          INTEGER NOT NULL,
+    PRIMARY KEY (stock_movement_id, batch_id)
+);
+
+ALTER TABLE goods_receipt_lines ADD COLUMN IF NOT EXISTS batch_id INTEGER REFERENCES product_batches (id);
@@ -0,0 +1,16 @@
+-- Varian adalah produk anak (parent_id) dengan harga, stok dan SKU sendiri, sehingga ledger, batch,
+-- keranjang dan opname tetap bekerja per baris produk.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES products (id);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS option_values JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON products (parent_id) WHERE deleted_at IS NULL;
+
+-- Jenis opsi pada produk induk, mis. Ukuran: S, M, L
+CREATE TABLE IF NOT EXISTS product_options (
+    id         SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products (id),
+    name       TEXT NOT NULL,
+    "values"   JSONB NOT NULL DEFAULT '[]',
+    position   INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (product_id, name)
+);
@@ -0,0 +1,22 @@
+-- Stok selalu disimpan dalam satuan dasar produk (integer). Barang timbang memakai satuan dasar terkecil
+-- (mis. gram) dan menjual per kg lewat satuan dengan factor 1000 yang boleh pecahan.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS base_unit TEXT NOT NULL DEFAULT 'pcs';
+
+-- Satuan tambahan selain satuan dasar, mis. pack = 6 pcs, dus = 24 pcs
+CREATE TABLE IF NOT EXISTS product_units (
+    id         SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products (id),
+    unit       TEXT NOT NULL,
+    factor     INTEGER NOT NULL CHECK (factor > 0),
+    price      BIGINT NOT NULL DEFAULT 0,
+    sellable   BOOLEAN NOT NULL DEFAULT TRUE,
+    fractional BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (product_id, unit)
+);
+
+-- Satuan jual per baris transaksi; quantity tetap dalam satuan dasar
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit TEXT NOT NULL DEFAULT 'pcs';
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_factor INTEGER NOT NULL DEFAULT 1;
+
+-- Satuan yang dipilih di keranjang, kosong berarti satuan dasar; quantity tetap dalam satuan dasar
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS unit TEXT NOT NULL DEFAULT '';
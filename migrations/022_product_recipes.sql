@@ -0,0 +1,23 @@
+-- Resep / isi paket: menjual produk komposit mengurangi stok komponennya, bukan stok produk itu sendiri.
+-- quantity dalam satuan dasar komponen per satu satuan dasar produk komposit.
+CREATE TABLE IF NOT EXISTS product_components (
+    id           SERIAL PRIMARY KEY,
+    product_id   INTEGER NOT NULL REFERENCES products (id),
+    component_id INTEGER NOT NULL REFERENCES products (id),
+    quantity     INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (product_id, component_id),
+    CHECK (product_id <> component_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_components_component_id ON product_components (component_id);
+
+-- Snapshot komponen yang dipakai setiap baris transaksi, dipakai saat refund mengembalikan stok
+CREATE TABLE IF NOT EXISTS transaction_detail_components (
+    id                    SERIAL PRIMARY KEY,
+    transaction_detail_id INTEGER NOT NULL REFERENCES transaction_details (id) ON DELETE CASCADE,
+    component_id          INTEGER NOT NULL REFERENCES products (id),
+    component_name        TEXT NOT NULL,
+    quantity              INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_components_detail_id ON transaction_detail_components (transaction_detail_id);
@@ -0,0 +1,39 @@
+-- SKU unik per produk aktif. SKU ganda yang sudah ada (kecuali yang ID-nya paling kecil) diberi akhiran ID produk
+-- agar index bisa dibuat. Jika hasilnya ternyata sudah dipakai produk lain, ditambah nomor urut sampai unik.
+DO $$
+DECLARE
+    dup       RECORD;
+    candidate TEXT;
+    n         INTEGER;
+BEGIN
+    FOR dup IN
+        SELECT p.id, p.sku FROM products p
+        WHERE p.deleted_at IS NULL AND p.sku IS NOT NULL
+          AND EXISTS (SELECT 1 FROM products o WHERE o.sku = p.sku AND o.id < p.id AND o.deleted_at IS NULL)
+        ORDER BY p.id
+    LOOP
+        candidate := dup.sku || '-' || dup.id;
+        n := 1;
+        WHILE EXISTS (SELECT 1 FROM products WHERE sku = candidate AND deleted_at IS NULL) LOOP
+            n := n + 1;
+            candidate := dup.sku || '-' || dup.id || '-' || n;
+        END LOOP;
+        UPDATE products SET sku = candidate WHERE id = dup.id;
+    END LOOP;
+END $$;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products (sku) WHERE deleted_at IS NULL AND sku IS NOT NULL;
+
+-- Satu produk bisa punya banyak barcode (EAN-13, UPC-A, internal)
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    id         SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products (id),
+    code       TEXT NOT NULL,
+    type       TEXT NOT NULL CHECK (type IN ('ean13', 'upc', 'internal')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_barcodes_code ON product_barcodes (code);
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product_id ON product_barcodes (product_id);
+
+-- Nomor urut barcode internal (prefix GS1 20, khusus pemakaian dalam toko)
+CREATE SEQUENCE IF NOT EXISTS internal_barcode_seq;
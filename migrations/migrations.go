@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"io/fs"
+	"log"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Run menjalankan file *.sql yang belum tercatat di tabel schema_migrations,
+// berurutan sesuai nama file. Setiap file dijalankan dalam satu transaksi.
+func Run(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		version := entry.Name()
+
+		var applied bool
+		err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+		if err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+
+		content, err := files.ReadFile(version)
+		if err != nil {
+			return err
+		}
+
+		if err := apply(db, version, string(content)); err != nil {
+			return err
+		}
+		log.Printf("Migration %s applied", version)
+	}
+	return nil
+}
+
+func apply(db *sql.DB, version, content string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(content); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Status keranjang
+const (
+	CartStatusOpen       = "open"
+	CartStatusCheckedOut = "checked_out"
+	CartStatusExpired    = "expired"
+	CartStatusCancelled  = "cancelled"
+)
+
+// Cart adalah keranjang yang diparkir sampai customer siap membayar. Harga dan stok di Items
+// selalu diambil dari produk saat ini; harga baru dikunci saat checkout.
+// Jika ReserveStock true, quantity di keranjang tidak bisa dibeli checkout lain sampai ExpiresAt.
+type Cart struct {
+	ID            int        `json:"id"`
+	Status        string     `json:"status"`
+	Customer      string     `json:"customer"`
+	Cashier       string     `json:"cashier"`
+	Note          string     `json:"note"`
+	ReserveStock  bool       `json:"reserve_stock"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	TransactionID *int       `json:"transaction_id,omitempty"`
+	Total         Money      `json:"total" swaggertype:"number"` // Harga saat ini sebelum promosi dan pajak
+	Items         []CartItem `json:"items"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type CartItem struct {
+	ProductID    int     `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	Unit         string  `json:"unit"`
+	UnitPrice    Money   `json:"unit_price" swaggertype:"number"` // Harga per satuan Unit
+	Quantity     float64 `json:"quantity"`                        // Dalam satuan Unit
+	BaseQuantity int     `json:"base_quantity"`                   // Dalam satuan dasar
+	Subtotal     Money   `json:"subtotal" swaggertype:"number"`
+	Available    int     `json:"available"` // Stok dalam satuan dasar yang bisa dibeli keranjang ini (stok dikurangi reservasi keranjang lain)
+}
+
+type CreateCartRequest struct {
+	Customer     string         `json:"customer"`
+	Note         string         `json:"note"`
+	ReserveStock bool           `json:"reserve_stock"`
+	Items        []CheckoutItem `json:"items"`
+
+	Cashier string `json:"-"` // Dari header X-User
+}
+
+type CartItemRequest struct {
+	ProductID int     `json:"product_id"`
+	Barcode   string  `json:"barcode"` // Hasil scan, pengganti product_id
+	Unit      string  `json:"unit"`    // Kosong berarti satuan dasar
+	Quantity  float64 `json:"quantity"`
+}
+
+type CartItemQuantityRequest struct {
+	Unit     string  `json:"unit"`     // Kosong berarti satuan dasar
+	Quantity float64 `json:"quantity"` // 0 menghapus produk dari keranjang
+}
+
+type CartCheckoutRequest struct {
+	Payments    []PaymentRequest `json:"payments"`
+	VoucherCode string           `json:"voucher_code"`
+}
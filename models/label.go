@@ -0,0 +1,49 @@
+package models
+
+// Format file label
+const (
+	LabelFormatPDF = "pdf"
+	LabelFormatPNG = "png"
+)
+
+// Simbologi barcode label. Auto memakai EAN-13 untuk kode EAN/UPC yang valid dan Code128 untuk kode lain.
+const (
+	LabelSymbologyAuto    = "auto"
+	LabelSymbologyCode128 = "code128"
+)
+
+// LabelRequest meminta label rak untuk beberapa produk. Tanpa Sheet, dipakai layout preset Layout
+// (lihat GET /labels/layouts). PNG hanya berisi satu halaman, dipilih dengan Page.
+type LabelRequest struct {
+	Items     []LabelItem `json:"items" binding:"required"`
+	Format    string      `json:"format" example:"pdf"`        // pdf (default) atau png
+	Layout    string      `json:"layout" example:"a4-3x7"`     // Nama layout preset, default a4-3x7
+	Sheet     *LabelSheet `json:"sheet,omitempty"`             // Layout custom, menggantikan Layout
+	Symbology string      `json:"symbology" example:"auto"`    // auto (default) atau code128
+	DPI       int         `json:"dpi,omitempty" example:"203"` // Resolusi PNG, default 203
+	Page      int         `json:"page,omitempty" example:"1"`  // Halaman PNG, default 1
+	Border    bool        `json:"border"`                      // Cetak garis tepi label untuk kertas polos
+}
+
+// LabelItem adalah produk yang dicetak. Tanpa Barcode dipakai barcode pertama produk, atau SKU jika produk
+// belum punya barcode.
+type LabelItem struct {
+	ProductID int    `json:"product_id" binding:"required"`
+	Barcode   string `json:"barcode"`
+	Copies    int    `json:"copies" example:"1"` // Default 1
+}
+
+// LabelSheet adalah tata letak lembar label. Semua ukuran dalam milimeter, label diisi baris demi baris.
+type LabelSheet struct {
+	Name        string  `json:"name" example:"a4-3x7"`
+	PageWidth   float64 `json:"page_width" example:"210"`
+	PageHeight  float64 `json:"page_height" example:"297"`
+	Columns     int     `json:"columns" example:"3"`
+	Rows        int     `json:"rows" example:"7"`
+	LabelWidth  float64 `json:"label_width" example:"63.5"`
+	LabelHeight float64 `json:"label_height" example:"38.1"`
+	MarginLeft  float64 `json:"margin_left" example:"7.2"`
+	MarginTop   float64 `json:"margin_top" example:"15.15"`
+	GapX        float64 `json:"gap_x" example:"2.5"`
+	GapY        float64 `json:"gap_y"`
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency adalah mata uang toko. Semua kolom nominal di database disimpan dalam minor unit mata uang ini.
+const DefaultCurrency = "IDR"
+
+// ErrCurrencyMismatch dikembalikan saat data memakai mata uang selain DefaultCurrency. Nominal dengan mata uang
+// berbeda tidak boleh digabung tanpa konversi kurs; Add, Sub dan LessThan panic dengan error ini sebagai
+// invariant internal, karena semua nominal sudah diperiksa saat dibaca dari request dan database.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Jumlah digit desimal minor unit per mata uang. Rupiah memakai 0: sen tidak beredar,
+// sehingga minor unit Rupiah adalah 1 rupiah.
+var currencyExponents = map[string]int{
+	"IDR": 0,
+	"USD": 2,
+	"SGD": 2,
+	"MYR": 2,
+}
+
+// Money adalah nominal uang dalam minor unit (integer) beserta kode mata uang ISO 4217.
+// Di JSON ditulis sebagai angka dalam major unit (mis. 15000 untuk Rp15.000), di database sebagai BIGINT minor unit.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Rupiah membuat Money dalam Rupiah dari nominal rupiah utuh.
+func Rupiah(amount int64) Money {
+	return Money{Amount: amount, Currency: DefaultCurrency}
+}
+
+// MoneyFromFloat mengubah nominal major unit menjadi Money. Aturan pembulatan: ke minor unit terdekat,
+// setengah dibulatkan menjauhi nol (Rp1.250,5 menjadi Rp1.251).
+func MoneyFromFloat(major float64, currency string) Money {
+	scale := math.Pow10(exponent(currency))
+	return Money{Amount: int64(math.Round(major * scale)), Currency: currency}
+}
+
+// ParseCurrency memeriksa kode mata uang yang dibaca dari database (mis. kolom transactions.currency).
+// Toko hanya memakai DefaultCurrency, jadi mata uang lain ditolak sebelum nominalnya ikut dihitung.
+// Kode kosong dianggap DefaultCurrency.
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	if code != DefaultCurrency {
+		return "", fmt.Errorf("%w: %s, store currency is %s", ErrCurrencyMismatch, code, DefaultCurrency)
+	}
+	return code, nil
+}
+
+func exponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// sameCurrency mengembalikan mata uang m dan o, panic jika berbeda. Currency kosong dianggap DefaultCurrency.
+func (m Money) sameCurrency(o Money) string {
+	if m.currency() != o.currency() {
+		panic(fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency(), o.currency()))
+	}
+	return m.currency()
+}
+
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.sameCurrency(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.sameCurrency(o)}
+}
+
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.currency()}
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.currency()}
+}
+
+// MulRatio mengalikan dengan num/den dan membulatkan setengah menjauhi nol, dipakai untuk
+// pembagian proporsional seperti refund sebagian atau persentase.
+func (m Money) MulRatio(num, den int64) Money {
+	if den == 0 {
+		return Money{Currency: m.currency()}
+	}
+	product := m.Amount * num
+	q, r := product/den, product%den
+	if r != 0 && 2*abs(r) >= abs(den) {
+		if (product < 0) != (den < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money{Amount: q, Currency: m.currency()}
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+func (m Money) LessThan(o Money) bool {
+	m.sameCurrency(o)
+	return m.Amount < o.Amount
+}
+
+// Float mengembalikan nominal dalam major unit, hanya untuk perhitungan rasio (mis. margin).
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(exponent(m.currency()))
+}
+
+// String menulis nominal dalam major unit tanpa pemisah ribuan, mis. "15000" atau "12.50".
+func (m Money) String() string {
+	exp := exponent(m.currency())
+	if exp == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+	return strconv.FormatFloat(m.Float(), 'f', exp, 64)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON menerima angka atau string angka dalam major unit DefaultCurrency. Nominal dari request tidak
+// membawa mata uang, sehingga selalu DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		*m = Money{Currency: DefaultCurrency}
+		return nil
+	}
+	var major float64
+	if err := json.Unmarshal([]byte(raw), &major); err != nil {
+		return fmt.Errorf("invalid money value %s", data)
+	}
+	*m = MoneyFromFloat(major, DefaultCurrency)
+	return nil
+}
+
+// Scan membaca kolom BIGINT/INTEGER minor unit DefaultCurrency. Kolom NUMERIC lama dibulatkan dengan aturan
+// yang sama. Baris yang punya kolom mata uang sendiri harus diperiksa dengan ParseCurrency.
+func (m *Money) Scan(src any) error {
+	m.Currency = DefaultCurrency
+	switch v := src.(type) {
+	case nil:
+		m.Amount = 0
+	case int64:
+		m.Amount = v
+	case float64:
+		m.Amount = int64(math.Round(v))
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return nil
+}
+
+func (m *Money) scanString(v string) error {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Money", v)
+	}
+	m.Amount = int64(math.Round(f))
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
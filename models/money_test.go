@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoneyMulRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		num, den int64
+		want     int64
+	}{
+		{"exact", 15000, 1100, 10000, 1650},
+		{"round down", 10, 1, 3, 3},
+		{"round up", 10, 2, 3, 7},
+		{"half away from zero", 5, 1, 2, 3},
+		{"negative half away from zero", -5, 1, 2, -3},
+		{"negative ratio", 7, -1, 2, -4},
+		{"negative denominator", 7, 1, -2, -4},
+		{"zero denominator", 1000, 1, 0, 0},
+		{"price included tax", 11100, 10000, 11100, 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Rupiah(tt.amount).MulRatio(tt.num, tt.den)
+			if got.Amount != tt.want || got.Currency != DefaultCurrency {
+				t.Errorf("Rupiah(%d).MulRatio(%d, %d) = %d %s, want %d %s", tt.amount, tt.num, tt.den, got.Amount, got.Currency, tt.want, DefaultCurrency)
+			}
+		})
+	}
+}
+
+func TestMoneyFromFloatRounding(t *testing.T) {
+	if got := MoneyFromFloat(1250.5, "IDR"); got.Amount != 1251 {
+		t.Errorf("MoneyFromFloat(1250.5, IDR) = %d, want 1251", got.Amount)
+	}
+	if got := MoneyFromFloat(-1250.5, "IDR"); got.Amount != -1251 {
+		t.Errorf("MoneyFromFloat(-1250.5, IDR) = %d, want -1251", got.Amount)
+	}
+	if got := MoneyFromFloat(12.5, "USD"); got.Amount != 1250 || got.String() != "12.50" {
+		t.Errorf("MoneyFromFloat(12.5, USD) = %d (%s), want 1250 (12.50)", got.Amount, got)
+	}
+}
+
+func TestMoneyEmptyCurrencyIsDefault(t *testing.T) {
+	got := Money{Amount: 500}.Add(Rupiah(1000))
+	if got.Amount != 1500 || got.Currency != DefaultCurrency {
+		t.Errorf("Add = %d %s, want 1500 %s", got.Amount, got.Currency, DefaultCurrency)
+	}
+}
+
+func TestMoneyCurrencyMismatchPanics(t *testing.T) {
+	usd := Money{Amount: 100, Currency: "USD"}
+	ops := map[string]func(){
+		"Add":      func() { Rupiah(100).Add(usd) },
+		"Sub":      func() { Rupiah(100).Sub(usd) },
+		"LessThan": func() { Rupiah(100).LessThan(usd) },
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrCurrencyMismatch) {
+					t.Errorf("%s with different currencies: recovered %v, want ErrCurrencyMismatch", name, err)
+				}
+			}()
+			op()
+		})
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    string
+		wantErr error
+	}{
+		{"IDR", "IDR", nil},
+		{"idr ", "IDR", nil},
+		{"", DefaultCurrency, nil},
+		{"USD", "", ErrCurrencyMismatch},
+		{"SGD", "", ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrency(tt.code)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("ParseCurrency(%q) = %q, %v, want %q, %v", tt.code, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// Nominal dari request selalu DefaultCurrency; nilai yang bukan angka ditolak dengan error, bukan panic.
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{`15000`, 15000, false},
+		{`"15000"`, 15000, false},
+		{`1250.5`, 1251, false},
+		{`null`, 0, false},
+		{`"USD 12.50"`, 0, true},
+		{`{"amount": 100, "currency": "USD"}`, 0, true},
+	}
+	for _, tt := range tests {
+		var m Money
+		err := m.UnmarshalJSON([]byte(tt.input))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("UnmarshalJSON(%s) error = %v, want error %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (m.Amount != tt.want || m.Currency != DefaultCurrency) {
+			t.Errorf("UnmarshalJSON(%s) = %d %s, want %d %s", tt.input, m.Amount, m.Currency, tt.want, DefaultCurrency)
+		}
+	}
+}
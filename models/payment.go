@@ -0,0 +1,26 @@
+package models
+
+// Metode pembayaran yang diterima kasir
+const (
+	PaymentMethodCash      = "cash"
+	PaymentMethodDebitCard = "debit_card"
+	PaymentMethodQRIS      = "qris"
+	PaymentMethodEWallet   = "e_wallet"
+	PaymentMethodVoucher   = "voucher"
+)
+
+type Payment struct {
+	ID            int    `json:"id"`
+	TransactionID int    `json:"transaction_id"`
+	Method        string `json:"method"`
+	Amount        Money  `json:"amount" swaggertype:"number"`   // Nominal yang dipakai untuk membayar tagihan
+	Tendered      Money  `json:"tendered" swaggertype:"number"` // Nominal yang diserahkan customer
+	Change        Money  `json:"change" swaggertype:"number"`   // Kembalian, hanya untuk cash
+	Reference     string `json:"reference,omitempty"`
+}
+
+type PaymentRequest struct {
+	Method    string `json:"method" example:"cash"`
+	Amount    Money  `json:"amount" example:"50000" swaggertype:"number"`
+	Reference string `json:"reference,omitempty"`
+}
@@ -1,16 +1,123 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Product struct {
-	ID          int        `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Price       float64    `json:"price"`
-	Stock       int        `json:"stock"`
-	CategoryID  int        `json:"category_id"`
-	Category    *Category  `json:"category,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"-"`
+	ID              int                `json:"id"`
+	Name            string             `json:"name"`
+	SKU             string             `json:"sku"`
+	Description     string             `json:"description"`
+	Price           Money              `json:"price" swaggertype:"number"`
+	CostPrice       Money              `json:"cost_price" swaggertype:"number"`
+	Stock           int                `json:"stock"`     // Dalam satuan dasar
+	BaseUnit        string             `json:"base_unit"` // Satuan dasar stok dan harga, default pcs
+	CategoryID      int                `json:"category_id"`
+	Category        *Category          `json:"category,omitempty"`
+	TaxCategoryID   *int               `json:"tax_category_id"` // Kosong berarti tarif PPN default toko
+	TaxExempt       bool               `json:"tax_exempt"`
+	ReorderPoint    int                `json:"reorder_point"`           // Stok minimum sebelum perlu pesan ulang, 0 berarti tidak dipantau
+	ReorderQuantity int                `json:"reorder_quantity"`        // Jumlah yang disarankan saat pesan ulang
+	Barcodes        []ProductBarcode   `json:"barcodes"`                // Diisi saat membuat produk; setelah itu lewat /products/:id/barcodes
+	ParentID        *int               `json:"parent_id,omitempty"`     // Diisi jika produk ini varian dari produk lain
+	OptionValues    OptionSet          `json:"option_values,omitempty"` // Nilai opsi varian, mis. {"Ukuran": "L"}
+	Options         []ProductOption    `json:"options,omitempty"`       // Jenis opsi pada produk induk
+	Variants        []Product          `json:"variants,omitempty"`      // Matriks varian, hanya pada GET /products/:id
+	Units           []ProductUnit      `json:"units,omitempty"`         // Satuan tambahan, hanya pada GET /products/:id
+	Composite       bool               `json:"composite"`               // Punya resep; penjualan mengurangi stok komponen
+	Available       *int               `json:"available,omitempty"`     // Untuk produk komposit: jumlah yang bisa dibuat dari stok komponen
+	Components      []ProductComponent `json:"components,omitempty"`    // Resep, hanya pada GET /products/:id
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	DeletedAt       *time.Time         `json:"-"`
+}
+
+// LowStockProduct adalah produk yang stoknya sudah di bawah atau sama dengan reorder point.
+type LowStockProduct struct {
+	ProductID       int    `json:"product_id"`
+	Name            string `json:"name"`
+	SKU             string `json:"sku"`
+	Stock           int    `json:"stock"`
+	ReorderPoint    int    `json:"reorder_point"`
+	ReorderQuantity int    `json:"reorder_quantity"`
+}
+
+// OptionSet memetakan nama opsi ke nilainya.
+type OptionSet map[string]string
+
+// ProductOption adalah satu jenis opsi varian beserta nilai yang diizinkan.
+type ProductOption struct {
+	Name   string   `json:"name" example:"Ukuran"`
+	Values []string `json:"values" example:"S,M,L"`
+}
+
+type ProductOptionsRequest struct {
+	Options []ProductOption `json:"options"`
+}
+
+// CreateVariantRequest membuat varian baru. Kategori dan pengaturan pajak mengikuti produk induk.
+type CreateVariantRequest struct {
+	OptionValues OptionSet `json:"option_values" binding:"required"`
+	SKU          string    `json:"sku"`
+	Barcode      string    `json:"barcode"`
+	Price        Money     `json:"price" swaggertype:"number"`
+	CostPrice    Money     `json:"cost_price" swaggertype:"number"`
+	Stock        int       `json:"stock"`
+}
+
+// DefaultBaseUnit dipakai jika produk dibuat tanpa base_unit.
+const DefaultBaseUnit = "pcs"
+
+// ProductUnit adalah satuan tambahan produk, mis. dus berisi 24 pcs. Harga berlaku per satu satuan ini.
+type ProductUnit struct {
+	Unit       string `json:"unit" example:"dus"`
+	Factor     int    `json:"factor" example:"24"`        // Jumlah satuan dasar dalam satu satuan ini
+	Price      Money  `json:"price" swaggertype:"number"` // Kosong atau 0 berarti harga dasar × factor saat disimpan
+	Sellable   bool   `json:"sellable" default:"true"`    // Bisa dipakai saat checkout, false untuk satuan beli saja
+	Fractional bool   `json:"fractional"`                 // Boleh dijual pecahan, mis. 0.25 kg
+}
+
+// UnmarshalJSON mengisi Sellable true jika tidak ada di JSON, sama dengan default kolom di database.
+func (u *ProductUnit) UnmarshalJSON(data []byte) error {
+	type plain ProductUnit
+	p := plain{Sellable: true}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*u = ProductUnit(p)
+	return nil
+}
+
+type ProductUnitsRequest struct {
+	Units []ProductUnit `json:"units"`
+}
+
+// ProductComponent adalah satu bahan / isi paket dalam resep produk komposit.
+// Quantity dalam satuan dasar komponen untuk satu satuan dasar produk komposit.
+type ProductComponent struct {
+	ComponentID int    `json:"component_id" binding:"required"`
+	Name        string `json:"name"`
+	BaseUnit    string `json:"base_unit"`
+	Quantity    int    `json:"quantity"`
+	Stock       int    `json:"stock"`
+}
+
+type ProductRecipeRequest struct {
+	Components []ProductComponent `json:"components"`
+}
+
+// Jenis barcode produk
+const (
+	BarcodeTypeEAN13    = "ean13"
+	BarcodeTypeUPC      = "upc"      // UPC-A 12 digit
+	BarcodeTypeInternal = "internal" // Kode buatan toko, dicetak sebagai Code128 atau EAN-13 prefix 20
+)
+
+type ProductBarcode struct {
+	ProductID int       `json:"product_id"`
+	Code      string    `json:"code" binding:"required" example:"8991234567891"`
+	Type      string    `json:"type" example:"ean13"` // Kosong berarti ditebak dari panjang kode
+	CreatedAt time.Time `json:"created_at"`
 }
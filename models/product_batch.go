@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ProductBatch adalah satu lot barang dengan tanggal kadaluarsa. Jumlah semua batch produk tidak pernah
+// melebihi Product.Stock; sisanya adalah stok tanpa batch.
+type ProductBatch struct {
+	ID              int       `json:"id"`
+	ProductID       int       `json:"product_id"`
+	ProductName     string    `json:"product_name,omitempty"`
+	BatchNumber     string    `json:"batch_number"`
+	ExpiresAt       *string   `json:"expires_at,omitempty" example:"2025-06-30"` // YYYY-MM-DD, barang masih boleh dijual pada tanggal ini
+	Quantity        int       `json:"quantity"`
+	InitialQuantity int       `json:"initial_quantity"`
+	GoodsReceiptID  *int      `json:"goods_receipt_id,omitempty"`
+	ReceivedAt      time.Time `json:"received_at"`
+	Expired         bool      `json:"expired"`
+	DaysToExpiry    *int      `json:"days_to_expiry,omitempty"`   // Negatif jika sudah lewat
+	Value           Money     `json:"value" swaggertype:"number"` // quantity x harga pokok produk saat ini
+}
+
+// StockMovementBatch adalah bagian movement yang mengenai satu batch.
+type StockMovementBatch struct {
+	BatchID     int     `json:"batch_id"`
+	BatchNumber string  `json:"batch_number"`
+	ExpiresAt   *string `json:"expires_at,omitempty"`
+	Quantity    int     `json:"quantity"`
+}
+
+type BatchWriteOffRequest struct {
+	Note string `json:"note"`
+	User string `json:"-"`
+}
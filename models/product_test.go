@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProductUnitSellableDefault(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{`{"unit": "dus", "factor": 24, "price": 60000}`, true},
+		{`{"unit": "dus", "factor": 24, "sellable": true}`, true},
+		{`{"unit": "dus", "factor": 24, "sellable": false}`, false},
+	}
+	for _, tt := range tests {
+		var u ProductUnit
+		if err := json.Unmarshal([]byte(tt.input), &u); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.input, err)
+		}
+		if u.Sellable != tt.want || u.Unit != "dus" || u.Factor != 24 {
+			t.Errorf("unmarshal %s = %+v, want sellable %v", tt.input, u, tt.want)
+		}
+	}
+
+	// Default juga berlaku untuk setiap elemen slice di request
+	var req ProductUnitsRequest
+	if err := json.Unmarshal([]byte(`{"units": [{"unit": "pack", "factor": 6}, {"unit": "dus", "factor": 24, "sellable": false}]}`), &req); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	if !req.Units[0].Sellable || req.Units[1].Sellable {
+		t.Errorf("units sellable = %v, %v, want true, false", req.Units[0].Sellable, req.Units[1].Sellable)
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis promosi
+const (
+	PromotionTypePercentage = "percentage"  // Diskon persen per produk / kategori
+	PromotionTypeFixed      = "fixed"       // Potongan nominal per unit per produk / kategori
+	PromotionTypeBuyXGetY   = "buy_x_get_y" // Beli X gratis Y untuk produk / kategori
+	PromotionTypeBundle     = "bundle"      // Harga paket untuk kombinasi produk
+	PromotionTypeMinSpend   = "min_spend"   // Diskon keranjang dengan minimal belanja
+)
+
+// Promotion adalah aturan diskon. Field yang dipakai tergantung Type:
+//   - percentage: DiscountPercent, ProductID atau CategoryID
+//   - fixed: DiscountAmount (per unit), ProductID atau CategoryID
+//   - buy_x_get_y: BuyQuantity, GetQuantity, ProductID atau CategoryID
+//   - bundle: BundleItems, BundlePrice
+//   - min_spend: MinSpend, dan DiscountPercent atau DiscountAmount
+//
+// StartsAt/EndsAt membatasi periode promo, StartTime/EndTime (HH:MM, zona waktu toko) dan
+// DaysOfWeek (0 = Minggu) membatasi jam berlaku seperti happy hour.
+// Unit dan quantity promosi (per unit, beli X, isi paket) dihitung dalam satuan dasar produk.
+type Promotion struct {
+	ID              int          `json:"id"`
+	Name            string       `json:"name" binding:"required"`
+	Type            string       `json:"type" binding:"required" example:"percentage"`
+	Active          bool         `json:"active" default:"true"`
+	ProductID       *int         `json:"product_id,omitempty"`
+	CategoryID      *int         `json:"category_id,omitempty"`
+	DiscountPercent float64      `json:"discount_percent,omitempty" example:"10"`
+	DiscountAmount  Money        `json:"discount_amount" swaggertype:"number"`
+	BuyQuantity     int          `json:"buy_quantity,omitempty"`
+	GetQuantity     int          `json:"get_quantity,omitempty"`
+	BundleItems     []BundleItem `json:"bundle_items,omitempty"`
+	BundlePrice     Money        `json:"bundle_price" swaggertype:"number"`
+	MinSpend        Money        `json:"min_spend" swaggertype:"number"`
+	StartsAt        *time.Time   `json:"starts_at,omitempty"`
+	EndsAt          *time.Time   `json:"ends_at,omitempty"`
+	StartTime       string       `json:"start_time,omitempty" example:"15:00"`
+	EndTime         string       `json:"end_time,omitempty" example:"17:00"`
+	DaysOfWeek      []int        `json:"days_of_week,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// UnmarshalJSON mengisi Active true jika tidak ada di JSON, sama dengan default kolom di database.
+func (p *Promotion) UnmarshalJSON(data []byte) error {
+	type plain Promotion
+	raw := plain{Active: true}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = Promotion(raw)
+	return nil
+}
+
+type BundleItem struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+// AppliedPromotion adalah potongan dari satu promosi pada satu baris transaksi.
+type AppliedPromotion struct {
+	PromotionID   int    `json:"promotion_id"`
+	PromotionName string `json:"promotion_name"`
+	Amount        Money  `json:"amount" swaggertype:"number"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPromotionActiveDefault(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{`{"name": "Diskon kopi", "type": "percentage", "discount_percent": 10}`, true},
+		{`{"name": "Diskon kopi", "type": "percentage", "active": true}`, true},
+		{`{"name": "Diskon kopi", "type": "percentage", "active": false}`, false},
+	}
+	for _, tt := range tests {
+		var p Promotion
+		if err := json.Unmarshal([]byte(tt.input), &p); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.input, err)
+		}
+		if p.Active != tt.want || p.Name != "Diskon kopi" {
+			t.Errorf("unmarshal %s: active = %v, name = %q, want active %v", tt.input, p.Active, p.Name, tt.want)
+		}
+	}
+}
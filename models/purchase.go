@@ -0,0 +1,116 @@
+package models
+
+import "time"
+
+// Status purchase order
+const (
+	PurchaseOrderStatusOpen              = "open"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+type Supplier struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" binding:"required"`
+	Phone     string    `json:"phone"`
+	Email     string    `json:"email"`
+	Address   string    `json:"address"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PurchaseOrder struct {
+	ID           int                 `json:"id"`
+	SupplierID   int                 `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"`
+	Status       string              `json:"status" example:"open"`
+	ExpectedDate *string             `json:"expected_date,omitempty" example:"2025-01-31"` // Perkiraan tanggal barang datang (YYYY-MM-DD)
+	Note         string              `json:"note"`
+	TotalCost    Money               `json:"total_cost" swaggertype:"number"`    // Nilai pesanan dengan harga beli yang diharapkan
+	ReceivedCost Money               `json:"received_cost" swaggertype:"number"` // Nilai barang yang sudah diterima
+	CreatedBy    string              `json:"created_by"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+	Receipts     []GoodsReceipt      `json:"receipts"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int    `json:"id"`
+	ProductID        int    `json:"product_id"`
+	ProductName      string `json:"product_name"`
+	Quantity         int    `json:"quantity"`
+	ReceivedQuantity int    `json:"received_quantity"`
+	UnitCost         Money  `json:"unit_cost" swaggertype:"number"` // Harga beli yang diharapkan
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID   int                        `json:"supplier_id" binding:"required"`
+	ExpectedDate *string                    `json:"expected_date" example:"2025-01-31"`
+	Note         string                     `json:"note"`
+	Lines        []PurchaseOrderLineRequest `json:"lines" binding:"required"`
+	CreatedBy    string                     `json:"-"`
+}
+
+type PurchaseOrderLineRequest struct {
+	ProductID int   `json:"product_id"`
+	Quantity  int   `json:"quantity"`
+	UnitCost  Money `json:"unit_cost" swaggertype:"number"`
+}
+
+// GoodsReceipt adalah satu kali penerimaan barang untuk purchase order.
+type GoodsReceipt struct {
+	ID              int                `json:"id"`
+	PurchaseOrderID int                `json:"purchase_order_id"`
+	Note            string             `json:"note"`
+	ReceivedBy      string             `json:"received_by"`
+	TotalCost       Money              `json:"total_cost" swaggertype:"number"`
+	Lines           []GoodsReceiptLine `json:"lines"`
+	ReceivedAt      time.Time          `json:"received_at"`
+}
+
+type GoodsReceiptLine struct {
+	ID                  int   `json:"id"`
+	PurchaseOrderLineID int   `json:"purchase_order_line_id"`
+	ProductID           int   `json:"product_id"`
+	Quantity            int   `json:"quantity"`
+	UnitCost            Money `json:"unit_cost" swaggertype:"number"` // Harga beli aktual
+	BatchID             *int  `json:"batch_id,omitempty"`
+}
+
+type GoodsReceiptRequest struct {
+	Note       string                    `json:"note"`
+	Lines      []GoodsReceiptLineRequest `json:"lines" binding:"required"`
+	ReceivedBy string                    `json:"-"`
+}
+
+type GoodsReceiptLineRequest struct {
+	ProductID int    `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	UnitCost  *Money `json:"unit_cost,omitempty" swaggertype:"number"` // Kosong berarti sama dengan harga di purchase order
+
+	// Opsional: jika diisi, barang dicatat sebagai batch baru
+	BatchNumber string  `json:"batch_number,omitempty"`
+	ExpiresAt   *string `json:"expires_at,omitempty" example:"2025-06-30"`
+}
+
+// PurchaseReport merekap penerimaan barang per supplier dalam satu periode.
+type PurchaseReport struct {
+	StartDate time.Time                 `json:"start_date"`
+	EndDate   time.Time                 `json:"end_date"`
+	Timezone  string                    `json:"timezone"`
+	Suppliers []SupplierPurchaseSummary `json:"suppliers"`
+	Quantity  int                       `json:"quantity"`
+	TotalCost Money                     `json:"total_cost" swaggertype:"number"`
+}
+
+type SupplierPurchaseSummary struct {
+	SupplierID     int    `json:"supplier_id"`
+	SupplierName   string `json:"supplier_name"`
+	PurchaseOrders int    `json:"purchase_orders"`
+	Receipts       int    `json:"receipts"`
+	Quantity       int    `json:"quantity"`
+	TotalCost      Money  `json:"total_cost" swaggertype:"number"`
+}
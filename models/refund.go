@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	TransactionStatusCompleted = "completed"
+	TransactionStatusVoided    = "voided"
+
+	RefundTypeRefund = "refund"
+	RefundTypeVoid   = "void"
+)
+
+type Refund struct {
+	ID            int          `json:"id"`
+	TransactionID int          `json:"transaction_id"`
+	Type          string       `json:"type"`
+	Reason        string       `json:"reason"`
+	User          string       `json:"user"`
+	TotalAmount   Money        `json:"total_amount" swaggertype:"number"` // Selalu negatif
+	CreatedAt     time.Time    `json:"created_at"`
+	Items         []RefundItem `json:"items"`
+}
+
+type RefundItem struct {
+	ID                  int    `json:"id"`
+	RefundID            int    `json:"refund_id"`
+	TransactionDetailID int    `json:"transaction_detail_id"`
+	ProductID           int    `json:"product_id"`
+	ProductName         string `json:"product_name,omitempty"`
+	Quantity            int    `json:"quantity"`
+	Amount              Money  `json:"amount" swaggertype:"number"` // Selalu negatif
+}
+
+type RefundItemRequest struct {
+	TransactionDetailID int `json:"transaction_detail_id"`
+	Quantity            int `json:"quantity"`
+}
+
+type RefundRequest struct {
+	Reason string              `json:"reason" binding:"required"`
+	Items  []RefundItemRequest `json:"items" binding:"required"`
+	User   string              `json:"-"`
+}
+
+type VoidRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	User   string `json:"-"`
+}
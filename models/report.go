@@ -1,12 +1,106 @@
 package models
 
+import "time"
+
+// Bagian laporan yang bisa dipilih lewat parameter sections
+const (
+	ReportSectionTopProducts = "top_products"
+	ReportSectionCategories  = "categories"
+	ReportSectionBasket      = "basket"
+	ReportSectionHourly      = "hourly"
+	ReportSectionProfit      = "profit"
+)
+
+// ReportQuery adalah rentang [Start, End) dan bagian laporan yang diminta.
+type ReportQuery struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+	Sections map[string]bool
+	TopN     int
+}
+
 type BestSellingProduct struct {
 	Name       string `json:"nama"`
 	QtyTerjual int    `json:"qty_terjual"`
 }
 
+type PaymentMethodSummary struct {
+	Method          string `json:"method"`
+	TotalTransaksi  int    `json:"total_transaksi"`
+	TotalPembayaran Money  `json:"total_pembayaran" swaggertype:"number"`
+}
+
+type ProductSales struct {
+	ProductID     int     `json:"product_id"`
+	Name          string  `json:"nama"`
+	QtyTerjual    int     `json:"qty_terjual"`
+	Revenue       Money   `json:"revenue" swaggertype:"number"`
+	Cost          Money   `json:"cost" swaggertype:"number"`
+	GrossProfit   Money   `json:"gross_profit" swaggertype:"number"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+type CategorySales struct {
+	CategoryID    int     `json:"category_id"`
+	Name          string  `json:"nama"`
+	QtyTerjual    int     `json:"qty_terjual"`
+	Revenue       Money   `json:"revenue" swaggertype:"number"`
+	Cost          Money   `json:"cost" swaggertype:"number"`
+	GrossProfit   Money   `json:"gross_profit" swaggertype:"number"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+// ProfitSummary dihitung dari unit_cost yang tersimpan di transaction_details, bukan cost_price produk saat ini.
+// Seperti ProductSales dan CategorySales, angkanya sudah dikurangi refund dan tidak termasuk transaksi yang di-void.
+type ProfitSummary struct {
+	Revenue       Money         `json:"revenue" swaggertype:"number"`
+	Cost          Money         `json:"cost" swaggertype:"number"`
+	GrossProfit   Money         `json:"gross_profit" swaggertype:"number"`
+	MarginPercent float64       `json:"margin_percent"`
+	PerHari       []DailyProfit `json:"per_hari"`
+}
+
+type DailyProfit struct {
+	Tanggal       string  `json:"tanggal"` // YYYY-MM-DD menurut zona waktu laporan
+	Revenue       Money   `json:"revenue" swaggertype:"number"`
+	Cost          Money   `json:"cost" swaggertype:"number"`
+	GrossProfit   Money   `json:"gross_profit" swaggertype:"number"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+// BasketStats dihitung dari transaksi yang tidak di-void; nilai keranjang (sebelum pajak dan service charge)
+// dan jumlah item sudah dikurangi refund.
+type BasketStats struct {
+	AvgBasketValue         Money   `json:"avg_basket_value" swaggertype:"number"`
+	AvgItemsPerTransaction float64 `json:"avg_items_per_transaction"`
+}
+
+// HourlySales dihitung dari transaksi yang tidak di-void; revenue sebelum pajak dan service charge dan sudah
+// dikurangi refund, sama seperti revenue per produk dan kategori.
+type HourlySales struct {
+	Hour           int   `json:"jam"` // 0-23 menurut zona waktu laporan
+	TotalTransaksi int   `json:"total_transaksi"`
+	Revenue        Money `json:"revenue" swaggertype:"number"`
+}
+
 type SalesReport struct {
-	TotalRevenue   int                `json:"total_revenue"`
-	TotalTransaksi int                `json:"total_transaksi"`
-	ProdukTerlaris BestSellingProduct `json:"produk_terlaris"`
+	StartDate      time.Time              `json:"start_date"` // Inklusif
+	EndDate        time.Time              `json:"end_date"`   // Eksklusif
+	Timezone       string                 `json:"timezone"`
+	TotalRevenue   Money                  `json:"total_revenue" swaggertype:"number"` // Sama dengan net_revenue
+	GrossRevenue   Money                  `json:"gross_revenue" swaggertype:"number"`
+	TotalRefund    Money                  `json:"total_refund" swaggertype:"number"` // Negatif
+	NetRevenue     Money                  `json:"net_revenue" swaggertype:"number"`
+	TotalTransaksi int                    `json:"total_transaksi"` // Tanpa transaksi yang di-void
+	ProdukTerlaris BestSellingProduct     `json:"produk_terlaris"`
+	Pembayaran     []PaymentMethodSummary `json:"pembayaran"`
+
+	// Bagian opsional, hanya terisi jika diminta lewat sections
+	TopByQuantity []ProductSales  `json:"top_by_quantity,omitempty"`
+	TopByRevenue  []ProductSales  `json:"top_by_revenue,omitempty"`
+	Categories    []CategorySales `json:"categories,omitempty"`
+	Basket        *BasketStats    `json:"basket,omitempty"`
+	Hourly        []HourlySales   `json:"hourly,omitempty"`
+	Profit        *ProfitSummary  `json:"profit,omitempty"`
 }
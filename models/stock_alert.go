@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Jenis notifier untuk alert stok menipis
+const (
+	StockNotifierLog     = "log"
+	StockNotifierWebhook = "webhook"
+	StockNotifierEmail   = "email"
+)
+
+// StockAlertConfig adalah pengaturan pengecekan stok menipis di background.
+type StockAlertConfig struct {
+	Notifier   string        // log, webhook atau email
+	WebhookURL string        // Tujuan POST JSON untuk notifier webhook
+	EmailTo    string        // Penerima untuk notifier email
+	Interval   time.Duration // Jarak antar pengecekan
+}
+
+// LowStockAlert dibuat saat penjualan membuat stok produk turun sampai reorder point.
+type LowStockAlert struct {
+	ID              int        `json:"id"`
+	ProductID       int        `json:"product_id"`
+	ProductName     string     `json:"product_name"`
+	SKU             string     `json:"sku"`
+	StockMovementID int64      `json:"stock_movement_id"`
+	Stock           int        `json:"stock"` // Stok setelah penjualan yang memicu alert
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	CreatedAt       time.Time  `json:"created_at"`
+	NotifiedAt      *time.Time `json:"notified_at,omitempty"`
+	Attempts        int        `json:"attempts"`             // Jumlah pengiriman yang sudah gagal
+	LastError       string     `json:"last_error,omitempty"` // Error pengiriman terakhir
+}
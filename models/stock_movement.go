@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// Jenis pergerakan stok
+const (
+	StockMovementOpening         = "opening"          // Saldo awal (stok saat produk dibuat / saat ledger mulai dipakai)
+	StockMovementSale            = "sale"             // Penjualan lewat checkout
+	StockMovementRefund          = "refund"           // Barang kembali karena refund / void
+	StockMovementPurchaseReceipt = "purchase_receipt" // Penerimaan barang dari supplier
+	StockMovementAdjustment      = "adjustment"       // Penyesuaian manual
+	StockMovementOpname          = "opname"           // Koreksi hasil stock opname
+	StockMovementTransfer        = "transfer"         // Pindah stok masuk / keluar toko
+)
+
+// StockMovement adalah satu baris ledger stok yang tidak pernah diubah atau dihapus.
+// Quantity positif menambah stok, negatif mengurangi. Jumlah Quantity per produk selalu sama dengan Product.Stock.
+type StockMovement struct {
+	ID          int       `json:"id"`
+	ProductID   int       `json:"product_id"`
+	Type        string    `json:"type" example:"sale"`
+	Quantity    int       `json:"quantity"`
+	StockAfter  int       `json:"stock_after"`
+	ReferenceID *int      `json:"reference_id,omitempty"` // ID transaksi / refund / dokumen sumber sesuai Type
+	Reason      string    `json:"reason,omitempty"`       // Kode alasan untuk penyesuaian manual
+	Note        string    `json:"note,omitempty"`
+	User        string    `json:"user,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Batches []StockMovementBatch `json:"batches,omitempty"`
+
+	// Batch tujuan: stok ditambahkan ke / diambil dari batch ini. Kosong berarti pengurangan diambil FEFO
+	// dan penambahan masuk ke stok tanpa batch.
+	BatchID *int `json:"-"`
+	// Untuk penjualan: batch yang kadaluarsa sebelum tanggal ini (YYYY-MM-DD, tanggal toko) tidak boleh dijual
+	SellableOn string `json:"-"`
+}
+
+// Kode alasan penyesuaian stok manual
+const (
+	StockReasonDamaged     = "damaged"      // Barang rusak
+	StockReasonExpired     = "expired"      // Barang kadaluarsa
+	StockReasonLost        = "lost"         // Hilang / dicuri
+	StockReasonFound       = "found"        // Barang ditemukan kembali
+	StockReasonCorrection  = "correction"   // Koreksi salah input, boleh plus atau minus
+	StockReasonTransferIn  = "transfer_in"  // Masuk dari toko / gudang lain
+	StockReasonTransferOut = "transfer_out" // Keluar ke toko / gudang lain
+	StockReasonOther       = "other"        // Lainnya, note wajib diisi
+)
+
+// StockAdjustmentRequest menambah atau mengurangi stok secara relatif terhadap stok saat ini.
+type StockAdjustmentRequest struct {
+	Quantity int    `json:"quantity" binding:"required" example:"-2"` // Positif menambah, negatif mengurangi
+	Reason   string `json:"reason" binding:"required" example:"damaged"`
+	Note     string `json:"note"`
+	User     string `json:"-"`
+
+	// Opsional: batch yang disesuaikan, atau batch baru untuk penambahan stok
+	BatchID     *int    `json:"batch_id,omitempty"`
+	BatchNumber string  `json:"batch_number,omitempty"`
+	ExpiresAt   *string `json:"expires_at,omitempty" example:"2025-06-30"`
+}
+
+// StockReconciliation adalah hasil pengecekan ledger terhadap stok produk.
+type StockReconciliation struct {
+	CheckedAt       time.Time       `json:"checked_at"`
+	ProductsChecked int             `json:"products_checked"`
+	Balanced        bool            `json:"balanced"`
+	Mismatches      []StockMismatch `json:"mismatches"`
+}
+
+type StockMismatch struct {
+	ProductID   int    `json:"product_id"`
+	Name        string `json:"name"`
+	Stock       int    `json:"stock"`
+	LedgerStock int    `json:"ledger_stock"`
+	Difference  int    `json:"difference"` // Stock - LedgerStock
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Status sesi stock opname
+const (
+	StockOpnameStatusCounting  = "counting"
+	StockOpnameStatusApproved  = "approved"
+	StockOpnameStatusCancelled = "cancelled"
+)
+
+// StockOpname adalah satu sesi hitung fisik. Stok sistem di-snapshot saat sesi dibuka dan dicatat lagi setiap
+// hitungan disimpan. Saat disetujui, selisih hitung terhadap stok sistem saat hitungan terakhir ditambahkan ke
+// stok berjalan: penjualan sebelum barang dihitung tidak terpotong dua kali, penjualan sesudahnya tetap terhitung.
+type StockOpname struct {
+	ID         int                `json:"id"`
+	Status     string             `json:"status" example:"counting"`
+	CategoryID *int               `json:"category_id,omitempty"` // Kosong berarti semua produk
+	BlockSales bool               `json:"block_sales"`           // true: checkout produk dalam sesi ditolak sampai sesi ditutup
+	Note       string             `json:"note"`
+	CreatedBy  string             `json:"created_by"`
+	ClosedBy   string             `json:"closed_by,omitempty"`
+	Summary    StockOpnameSummary `json:"summary"`
+	Items      []StockOpnameItem  `json:"items,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ClosedAt   *time.Time         `json:"closed_at,omitempty"`
+}
+
+type StockOpnameItem struct {
+	ProductID        int                `json:"product_id"`
+	ProductName      string             `json:"product_name"`
+	SKU              string             `json:"sku"`
+	ExpectedStock    int                `json:"expected_stock"`             // Stok sistem saat snapshot
+	CountedQuantity  *int               `json:"counted_quantity,omitempty"` // Jumlah hitungan semua petugas, kosong jika belum dihitung
+	StockAtCount     *int               `json:"stock_at_count,omitempty"`   // Stok sistem saat hitungan terakhir disimpan
+	Variance         int                `json:"variance"`                   // counted_quantity - stock_at_count, yang diposting saat approve
+	CostPrice        Money              `json:"cost_price" swaggertype:"number"`
+	VarianceValue    Money              `json:"variance_value" swaggertype:"number"` // variance x harga pokok saat snapshot
+	MovedDuringCount int                `json:"moved_during_count"`                  // Perubahan stok sejak snapshot (penjualan, penerimaan, dll.), hanya selama sesi berjalan
+	Flagged          bool               `json:"flagged"`                             // true jika stok bergerak selama hitung, hasil hitung perlu dicek ulang
+	Counts           []StockOpnameCount `json:"counts"`
+}
+
+type StockOpnameCount struct {
+	CountedBy   string    `json:"counted_by"`
+	Quantity    int       `json:"quantity"`
+	SystemStock int       `json:"system_stock"` // Stok sistem saat hitungan ini disimpan
+	CountedAt   time.Time `json:"counted_at"`
+}
+
+type StockOpnameSummary struct {
+	Products         int   `json:"products"`
+	Counted          int   `json:"counted"`
+	Uncounted        int   `json:"uncounted"`
+	WithVariance     int   `json:"with_variance"`
+	Flagged          int   `json:"flagged"`
+	VarianceQuantity int   `json:"variance_quantity"`
+	VarianceValue    Money `json:"variance_value" swaggertype:"number"`
+}
+
+type CreateStockOpnameRequest struct {
+	CategoryID *int   `json:"category_id"`
+	BlockSales bool   `json:"block_sales"`
+	Note       string `json:"note"`
+	CreatedBy  string `json:"-"`
+}
+
+// StockOpnameCountRequest berisi hasil hitung satu petugas. Petugas diambil dari header X-User.
+type StockOpnameCountRequest struct {
+	Counts    []StockOpnameCountItem `json:"counts" binding:"required"`
+	CountedBy string                 `json:"-"`
+}
+
+type StockOpnameCountItem struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// TaxConfig adalah pengaturan pajak toko. Rate dalam persen.
+type TaxConfig struct {
+	DefaultRate       float64 // PPN untuk produk tanpa kategori pajak
+	PricesIncludeTax  bool    // true jika harga jual produk sudah termasuk PPN
+	ServiceChargeRate float64 // Service charge dari harga sebelum pajak, 0 berarti tidak ada
+}
+
+// TaxCategory menentukan tarif PPN untuk sekelompok produk.
+type TaxCategory struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name" binding:"required"`
+	Rate        float64   `json:"rate" example:"11"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TaxSummary adalah rekap pajak untuk pelaporan, sudah dikurangi refund pada periode yang sama.
+type TaxSummary struct {
+	StartDate     time.Time        `json:"start_date"`
+	EndDate       time.Time        `json:"end_date"`
+	Timezone      string           `json:"timezone"`
+	Rates         []TaxRateSummary `json:"rates"`
+	TaxableAmount Money            `json:"taxable_amount" swaggertype:"number"` // Total DPP
+	TaxAmount     Money            `json:"tax_amount" swaggertype:"number"`
+	ServiceCharge Money            `json:"service_charge" swaggertype:"number"`
+	ExemptSales   Money            `json:"exempt_sales" swaggertype:"number"` // Penjualan bebas PPN (tarif 0)
+}
+
+type TaxRateSummary struct {
+	Rate          float64 `json:"rate"`
+	TaxableAmount Money   `json:"taxable_amount" swaggertype:"number"`
+	TaxAmount     Money   `json:"tax_amount" swaggertype:"number"`
+	ServiceCharge Money   `json:"service_charge" swaggertype:"number"`
+}
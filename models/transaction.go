@@ -2,27 +2,119 @@ package models
 
 import "time"
 
+// Transaction menyimpan rincian tagihan: TotalAmount (grand total) = Subtotal + ServiceCharge + TaxAmount.
+// Subtotal adalah harga setelah diskon sebelum pajak, juga saat harga produk sudah termasuk PPN (PricesIncludeTax).
 type Transaction struct {
-	ID          int                 `json:"id"`
-	TotalAmount int                 `json:"total_amount"`
-	CreatedAt   time.Time           `json:"created_at"`
-	Details     []TransactionDetail `json:"details"`
+	ID               int                 `json:"id"`
+	Subtotal         Money               `json:"subtotal" swaggertype:"number"`
+	ServiceCharge    Money               `json:"service_charge" swaggertype:"number"`
+	TaxAmount        Money               `json:"tax_amount" swaggertype:"number"`
+	TotalAmount      Money               `json:"total_amount" swaggertype:"number"`
+	PricesIncludeTax bool                `json:"prices_include_tax"`
+	VoucherCode      string              `json:"voucher_code,omitempty"`
+	VoucherDiscount  Money               `json:"voucher_discount" swaggertype:"number"` // Sudah termasuk di DiscountAmount setiap baris
+	PaidAmount       Money               `json:"paid_amount" swaggertype:"number"`
+	ChangeAmount     Money               `json:"change_amount" swaggertype:"number"`
+	Currency         string              `json:"currency" example:"IDR"`
+	Status           string              `json:"status"`
+	Cashier          string              `json:"cashier"`
+	Customer         string              `json:"customer,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+	Details          []TransactionDetail `json:"details"`
+	Payments         []Payment           `json:"payments"`
 }
 
+// TransactionDetail menyimpan snapshot nama, SKU, harga dan pajak produk saat transaksi terjadi.
+// Subtotal = UnitPrice * Quantity - DiscountAmount (harga jual, bisa sudah termasuk PPN).
+// TaxableAmount (DPP) = harga sebelum pajak + ServiceCharge, Total = TaxableAmount + TaxAmount.
 type TransactionDetail struct {
-	ID            int    `json:"id"`
-	TransactionID int    `json:"transaction_id"`
-	ProductID     int    `json:"product_id"`
-	ProductName   string `json:"product_name,omitempty"`
+	ID              int                `json:"id"`
+	TransactionID   int                `json:"transaction_id"`
+	ProductID       int                `json:"product_id"`
+	ProductName     string             `json:"product_name,omitempty"`
+	SKU             string             `json:"sku"`
+	CategoryID      int                `json:"category_id,omitempty"`                 // Kategori produk saat transaksi
+	UnitPrice       Money              `json:"unit_price" swaggertype:"number"`       // Harga per satuan Unit
+	Quantity        int                `json:"quantity"`                              // Dalam satuan dasar produk
+	Unit            string             `json:"unit"`                                  // Satuan jual
+	UnitFactor      int                `json:"unit_factor"`                           // Jumlah satuan dasar dalam satu Unit
+	UnitQuantity    float64            `json:"unit_quantity"`                         // Quantity dalam satuan Unit
+	DiscountAmount  Money              `json:"discount_amount" swaggertype:"number"`  // Promosi + VoucherDiscount
+	VoucherDiscount Money              `json:"voucher_discount" swaggertype:"number"` // Bagian potongan voucher transaksi
+	Subtotal        Money              `json:"subtotal" swaggertype:"number"`
+	ServiceCharge   Money              `json:"service_charge" swaggertype:"number"`
+	TaxCategoryID   int                `json:"tax_category_id,omitempty"`
+	TaxExempt       bool               `json:"tax_exempt,omitempty"`
+	TaxRate         float64            `json:"tax_rate"`
+	TaxableAmount   Money              `json:"taxable_amount" swaggertype:"number"`
+	TaxAmount       Money              `json:"tax_amount" swaggertype:"number"`
+	Total           Money              `json:"total" swaggertype:"number"`
+	UnitCost        Money              `json:"unit_cost" swaggertype:"number"` // Harga pokok per unit saat transaksi
+	Promotions      []AppliedPromotion `json:"promotions,omitempty"`           // Rincian potongan per promosi
+	Components      []DetailComponent  `json:"components,omitempty"`           // Stok komponen yang dipakai produk komposit
+}
+
+// DetailComponent adalah stok komponen (satuan dasar) yang dikurangi untuk satu baris produk komposit.
+type DetailComponent struct {
+	ComponentID   int    `json:"component_id"`
+	ComponentName string `json:"component_name"`
 	Quantity      int    `json:"quantity"`
-	Subtotal      int    `json:"subtotal"`
+}
+
+// PriceFor menghitung harga baseQuantity satuan dasar dari UnitPrice per satuan jual.
+func (d TransactionDetail) PriceFor(baseQuantity int) Money {
+	if d.UnitFactor <= 1 {
+		return d.UnitPrice.Mul(int64(baseQuantity))
+	}
+	return d.UnitPrice.MulRatio(int64(baseQuantity), int64(d.UnitFactor))
 }
 
 type CheckoutItem struct {
-	ProductID int `json:"product_id"`
-	Quantity  int `json:"quantity"`
+	ProductID int     `json:"product_id"`
+	VariantID int     `json:"variant_id,omitempty"` // Wajib untuk produk yang punya varian; product_id boleh diisi ID induknya
+	Barcode   string  `json:"barcode,omitempty"`    // Hasil scan, pengganti product_id / variant_id
+	Unit      string  `json:"unit,omitempty"`       // Satuan jual, kosong berarti satuan dasar
+	Quantity  float64 `json:"quantity"`             // Dalam satuan Unit, boleh pecahan untuk satuan fractional
+
+	ParentID     int `json:"-"` // ID induk yang harus cocok dengan varian, diisi dari product_id saat variant_id dipakai
+	BaseQuantity int `json:"-"` // Quantity tersimpan di keranjang, dalam satuan dasar
 }
 
 type CheckoutRequest struct {
-	Items []CheckoutItem `json:"items"`
+	Items       []CheckoutItem   `json:"items"`
+	Payments    []PaymentRequest `json:"payments"`
+	VoucherCode string           `json:"voucher_code"`
+	Customer    string           `json:"customer"` // ID / no. HP customer, wajib untuk voucher dengan batas per customer
+
+	// Diisi dari header Idempotency-Key dan X-User, bukan dari body
+	IdempotencyKey string `json:"-"`
+	RequestHash    string `json:"-"`
+	Cashier        string `json:"-"`
+
+	// Diisi service dari pengaturan pajak toko
+	PricesIncludeTax bool `json:"-"`
+	// Tanggal hari ini di zona waktu toko (YYYY-MM-DD), batch yang sudah kadaluarsa tidak ikut terjual
+	BusinessDate string `json:"-"`
+
+	// Diisi saat checkout dari keranjang (POST /carts/:id/checkout)
+	CartID int `json:"-"`
+}
+
+// TransactionFilter adalah parameter pencarian GET /transactions. Field kosong/nil berarti tidak difilter.
+type TransactionFilter struct {
+	StartDate     *time.Time
+	EndDate       *time.Time // Eksklusif
+	MinTotal      *Money
+	MaxTotal      *Money
+	ProductID     int
+	PaymentMethod string
+	Cashier       string
+	Sort          string
+	Cursor        string
+	Limit         int
+}
+
+type TransactionPage struct {
+	Data       []Transaction `json:"data"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
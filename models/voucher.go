@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis potongan voucher
+const (
+	VoucherTypePercentage = "percentage"
+	VoucherTypeFixed      = "fixed"
+)
+
+// Voucher adalah kode yang bisa ditukar saat checkout. MaxUses 1 berarti sekali pakai,
+// 0 berarti tanpa batas; MaxUsesPerCustomer membatasi pemakaian per customer (0 berarti tanpa batas).
+// MaxDiscount membatasi nilai potongan voucher persen (0 berarti tanpa batas).
+type Voucher struct {
+	ID                 int        `json:"id"`
+	Code               string     `json:"code" binding:"required" example:"HEMAT10"`
+	Description        string     `json:"description"`
+	Type               string     `json:"type" binding:"required" example:"percentage"`
+	DiscountPercent    float64    `json:"discount_percent,omitempty" example:"10"`
+	DiscountAmount     Money      `json:"discount_amount" swaggertype:"number"`
+	MaxDiscount        Money      `json:"max_discount" swaggertype:"number"`
+	MinPurchase        Money      `json:"min_purchase" swaggertype:"number"`
+	MaxUses            int        `json:"max_uses"`
+	MaxUsesPerCustomer int        `json:"max_uses_per_customer"`
+	UsedCount          int        `json:"used_count"`
+	Active             bool       `json:"active" default:"true"`
+	StartsAt           *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// UnmarshalJSON mengisi Active true jika tidak ada di JSON, sama dengan default kolom di database, sehingga
+// voucher baru langsung bisa dipakai.
+func (v *Voucher) UnmarshalJSON(data []byte) error {
+	type plain Voucher
+	raw := plain{Active: true}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*v = Voucher(raw)
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVoucherActiveDefault(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{`{"code": "HEMAT10", "type": "percentage", "discount_percent": 10}`, true},
+		{`{"code": "HEMAT10", "type": "percentage", "active": true}`, true},
+		{`{"code": "HEMAT10", "type": "percentage", "active": false}`, false},
+	}
+	for _, tt := range tests {
+		var v Voucher
+		if err := json.Unmarshal([]byte(tt.input), &v); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.input, err)
+		}
+		if v.Active != tt.want || v.Code != "HEMAT10" {
+			t.Errorf("unmarshal %s: active = %v, code = %q, want active %v", tt.input, v.Active, v.Code, tt.want)
+		}
+	}
+}
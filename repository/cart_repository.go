@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+var (
+	ErrCartNotFound      = errors.New("cart not found")
+	ErrCartNotOpen       = errors.New("cart is no longer open")
+	ErrCartEmpty         = errors.New("cart is empty")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrCartUnitMismatch  = errors.New("product is already in the cart with a different unit")
+)
+
+// reservedByOtherCarts adalah quantity produk p yang direservasi keranjang open lain yang belum kadaluarsa.
+// Reservasi produk komposit tidak memesan stok komponennya; ketersediaannya dicek ulang saat checkout.
+// cartRef adalah ekspresi ID keranjang yang sedang diproses (0 jika bukan dari keranjang).
+func reservedByOtherCarts(cartRef string) string {
+	return `COALESCE((
+		SELECT SUM(rci.quantity)
+		FROM cart_items rci
+		JOIN carts rc ON rci.cart_id = rc.id
+		WHERE rci.product_id = p.id AND rc.id <> ` + cartRef + `
+		  AND rc.status = 'open' AND rc.reserve_stock AND rc.expires_at > NOW()
+	), 0)`
+}
+
+type CartRepository interface {
+	Create(cart *models.Cart, items []models.CheckoutItem) error
+	FetchAll(status string) ([]models.Cart, error)
+	FetchByID(id int) (*models.Cart, error)
+	SetItem(cartID, productID int, unit string, quantity float64, add bool, expiresAt time.Time) error
+	Cancel(id int) error
+	ExpireCarts() (int64, error)
+}
+
+type cartRepository struct {
+	db *sql.DB
+}
+
+func NewCartRepository(db *sql.DB) *cartRepository {
+	return &cartRepository{db: db}
+}
+
+func (r *cartRepository) Create(cart *models.Cart, items []models.CheckoutItem) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO carts (status, customer, cashier, note, reserve_stock, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at
+	`, models.CartStatusOpen, cart.Customer, cart.Cashier, cart.Note, cart.ReserveStock, cart.ExpiresAt).
+		Scan(&cart.ID, &cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	cart.Status = models.CartStatusOpen
+
+	// Kunci semua produk sekaligus urut ID seperti checkout
+	if err := lockSaleProducts(tx, items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := setCartItem(tx, cart.ID, cart.ReserveStock, item.ProductID, item.ParentID, item.Unit, item.Quantity, false); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SetItem mengubah quantity satu produk di keranjang (add true berarti menambah quantity yang ada,
+// quantity 0 berarti menghapus baris) lalu memperpanjang masa berlaku keranjang sampai expiresAt.
+func (r *cartRepository) SetItem(cartID, productID int, unit string, quantity float64, add bool, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	reserve, err := lockOpenCart(tx, cartID)
+	if err != nil {
+		return err
+	}
+
+	if err := setCartItem(tx, cartID, reserve, productID, 0, unit, quantity, add); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE carts SET expires_at = $1, updated_at = NOW() WHERE id = $2", expiresAt, cartID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockOpenCart mengunci keranjang yang masih open dan belum kadaluarsa, mengembalikan reserve_stock-nya.
+func lockOpenCart(tx *sql.Tx, cartID int) (bool, error) {
+	var status string
+	var reserve bool
+	var expiresAt time.Time
+	err := tx.QueryRow("SELECT status, reserve_stock, expires_at FROM carts WHERE id = $1 FOR UPDATE", cartID).
+		Scan(&status, &reserve, &expiresAt)
+	if err == sql.ErrNoRows {
+		return false, ErrCartNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	if status != models.CartStatusOpen || !time.Now().Before(expiresAt) {
+		return false, ErrCartNotOpen
+	}
+	return reserve, nil
+}
+
+// fetchCartItems mengambil item keranjang untuk checkout; quantity keranjang sudah dalam satuan dasar.
+func fetchCartItems(tx *sql.Tx, cartID int) ([]models.CheckoutItem, error) {
+	rows, err := tx.Query("SELECT product_id, unit, quantity FROM cart_items WHERE cart_id = $1", cartID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.CheckoutItem, 0)
+	for rows.Next() {
+		var item models.CheckoutItem
+		if err := rows.Scan(&item.ProductID, &item.Unit, &item.BaseQuantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// setCartItem menyimpan quantity satu produk di keranjang dalam satuan unit (add true berarti menambah
+// quantity yang ada). parentID diisi jika item ditambahkan lewat variant_id dan harus cocok dengan induk varian tersebut.
+func setCartItem(tx *sql.Tx, cartID int, reserve bool, productID, parentID int, unit string, quantity float64, add bool) error {
+	if quantity <= 0 && !add {
+		_, err := tx.Exec("DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2", cartID, productID)
+		return err
+	}
+
+	// Kunci dan baca ketersediaan harus statement terpisah: di READ COMMITTED subquery reservasi memakai snapshot
+	// awal statement, jadi jika digabung reservasi keranjang lain yang di-commit selama menunggu kunci tidak terlihat
+	if err := lockSaleProducts(tx, []models.CheckoutItem{{ProductID: productID}}); err != nil {
+		return err
+	}
+
+	var name, baseUnit string
+	var price models.Money
+	var available, actualParentID int
+	var variants bool
+	err := tx.QueryRow(`
+		SELECT p.name, p.price, p.base_unit, COALESCE(`+compositeAvailable+`, p.stock - `+reservedByOtherCarts("$2")+`),
+		       COALESCE(p.parent_id, 0), `+hasActiveVariants+`
+		FROM products p
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`, productID, cartID).Scan(&name, &price, &baseUnit, &available, &actualParentID, &variants)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: product id %d", ErrProductNotFound, productID)
+	}
+	if err != nil {
+		return err
+	}
+	if variants {
+		return fmt.Errorf("%w: %s", ErrVariantRequired, name)
+	}
+	if parentID != 0 && parentID != actualParentID {
+		return fmt.Errorf("%w: variant id %d, product id %d", ErrVariantMismatch, productID, parentID)
+	}
+
+	u, err := lookupSaleUnit(tx, productID, unit, baseUnit, price)
+	if err != nil {
+		return err
+	}
+	base, err := u.baseQuantity(quantity)
+	if err != nil {
+		return err
+	}
+	// Satuan dasar disimpan sebagai string kosong
+	if unit == baseUnit {
+		unit = ""
+	}
+
+	if add {
+		var currentUnit string
+		var current int
+		err = tx.QueryRow("SELECT unit, quantity FROM cart_items WHERE cart_id = $1 AND product_id = $2", cartID, productID).
+			Scan(&currentUnit, &current)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil && currentUnit != unit {
+			return fmt.Errorf("%w: %s", ErrCartUnitMismatch, name)
+		}
+		base += current
+	}
+	if reserve && available < base {
+		return fmt.Errorf("%w for product %s", ErrInsufficientStock, name)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO cart_items (cart_id, product_id, unit, quantity)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET unit = EXCLUDED.unit, quantity = EXCLUDED.quantity
+	`, cartID, productID, unit, base)
+	return err
+}
+
+func (r *cartRepository) FetchAll(status string) ([]models.Cart, error) {
+	query := `
+		SELECT id, status, customer, cashier, note, reserve_stock, expires_at, transaction_id, created_at, updated_at
+		FROM carts
+	`
+	var args []interface{}
+	if status != "" {
+		query += " WHERE status = $1"
+		args = append(args, status)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carts := make([]models.Cart, 0)
+	for rows.Next() {
+		c, err := scanCart(rows)
+		if err != nil {
+			return nil, err
+		}
+		carts = append(carts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return carts, r.loadCartItems(carts)
+}
+
+func (r *cartRepository) FetchByID(id int) (*models.Cart, error) {
+	c, err := scanCart(r.db.QueryRow(`
+		SELECT id, status, customer, cashier, note, reserve_stock, expires_at, transaction_id, created_at, updated_at
+		FROM carts
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrCartNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	carts := []models.Cart{c}
+	if err := r.loadCartItems(carts); err != nil {
+		return nil, err
+	}
+	return &carts[0], nil
+}
+
+func scanCart(row rowScanner) (models.Cart, error) {
+	var c models.Cart
+	var transactionID sql.NullInt64
+	err := row.Scan(&c.ID, &c.Status, &c.Customer, &c.Cashier, &c.Note, &c.ReserveStock, &c.ExpiresAt, &transactionID, &c.CreatedAt, &c.UpdatedAt)
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		c.TransactionID = &id
+	}
+	return c, err
+}
+
+// loadCartItems mengisi Items dengan nama, harga per satuan dan stok tersedia produk saat ini.
+func (r *cartRepository) loadCartItems(carts []models.Cart) error {
+	if len(carts) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(carts))
+	index := make(map[int]int, len(carts))
+	for i := range carts {
+		ids[i] = carts[i].ID
+		index[carts[i].ID] = i
+		carts[i].Items = make([]models.CartItem, 0)
+		carts[i].Total = models.Rupiah(0)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT ci.cart_id, ci.product_id, p.name, COALESCE(NULLIF(ci.unit, ''), p.base_unit), COALESCE(pu.price, p.price),
+		       COALESCE(pu.factor, 1), ci.quantity, COALESCE(`+compositeAvailable+`, GREATEST(p.stock - `+reservedByOtherCarts("ci.cart_id")+`, 0))
+		FROM cart_items ci
+		JOIN products p ON ci.product_id = p.id
+		LEFT JOIN product_units pu ON pu.product_id = ci.product_id AND pu.unit = ci.unit
+		WHERE ci.cart_id = ANY($1)
+		ORDER BY ci.created_at, ci.product_id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cartID, factor int
+		var item models.CartItem
+		err := rows.Scan(&cartID, &item.ProductID, &item.ProductName, &item.Unit, &item.UnitPrice, &factor, &item.BaseQuantity, &item.Available)
+		if err != nil {
+			return err
+		}
+		item.Quantity = float64(item.BaseQuantity) / float64(factor)
+		item.Subtotal = item.UnitPrice.MulRatio(int64(item.BaseQuantity), int64(factor))
+
+		c := &carts[index[cartID]]
+		c.Items = append(c.Items, item)
+		c.Total = c.Total.Add(item.Subtotal)
+	}
+	return rows.Err()
+}
+
+func (r *cartRepository) Cancel(id int) error {
+	res, err := r.db.Exec("UPDATE carts SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3",
+		models.CartStatusCancelled, id, models.CartStatusOpen)
+	if err != nil {
+		return err
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		if _, err := r.FetchByID(id); err != nil {
+			return err
+		}
+		return ErrCartNotOpen
+	}
+	return nil
+}
+
+// ExpireCarts menandai keranjang open yang sudah lewat expires_at sebagai expired.
+// Reservasinya sudah tidak dihitung sejak expires_at, jadi ini hanya merapikan status.
+func (r *cartRepository) ExpireCarts() (int64, error) {
+	res, err := r.db.Exec("UPDATE carts SET status = $1, updated_at = NOW() WHERE status = $2 AND expires_at <= NOW()",
+		models.CartStatusExpired, models.CartStatusOpen)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"kasir-api/models"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Keranjang paralel yang mereservasi produk yang sama tidak boleh melebihi stok, baik saat keranjang dibuat
+// maupun saat item ditambahkan ke keranjang yang sudah ada.
+func TestCartConcurrentReservations(t *testing.T) {
+	const stock, carts, quantity = 5, 10, 2
+
+	db := testDB(t)
+	db.SetMaxOpenConns(carts)
+	repo := NewCartRepository(db)
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		reserve func(productID int) error
+	}{
+		{"create", func(productID int) error {
+			cart := models.Cart{ReserveStock: true, ExpiresAt: expiresAt}
+			return repo.Create(&cart, []models.CheckoutItem{{ProductID: productID, Quantity: quantity}})
+		}},
+		{"set item", func(productID int) error {
+			// Keranjang kosong dibuat lebih dulu supaya hanya SetItem yang berebut stok
+			cart := models.Cart{ReserveStock: true, ExpiresAt: expiresAt}
+			if err := repo.Create(&cart, nil); err != nil {
+				return err
+			}
+			return repo.SetItem(cart.ID, productID, "", quantity, false, expiresAt)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := createTestProduct(t, db, stock)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, carts)
+			start := make(chan struct{})
+			for i := 0; i < carts; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					errs <- tt.reserve(product.ID)
+				}()
+			}
+			close(start)
+			wg.Wait()
+			close(errs)
+
+			succeeded := 0
+			for err := range errs {
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.Is(err, ErrInsufficientStock):
+				default:
+					t.Errorf("unexpected reservation error: %v", err)
+				}
+			}
+			if want := stock / quantity; succeeded != want {
+				t.Errorf("succeeded reservations = %d, want %d", succeeded, want)
+			}
+
+			var reserved int
+			err := db.QueryRow(`
+				SELECT COALESCE(SUM(ci.quantity), 0)
+				FROM cart_items ci JOIN carts c ON c.id = ci.cart_id
+				WHERE ci.product_id = $1 AND c.status = 'open' AND c.reserve_stock
+			`, product.ID).Scan(&reserved)
+			if err != nil {
+				t.Fatalf("read reservations: %v", err)
+			}
+			if reserved > stock {
+				t.Errorf("reserved %d units of a product with stock %d", reserved, stock)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+)
+
+var ErrBatchEmpty = errors.New("batch has no remaining stock")
+
+type ProductBatchRepository interface {
+	FetchByProduct(productID int, includeEmpty bool) ([]models.ProductBatch, error)
+	FetchExpiring(until string) ([]models.ProductBatch, error)
+	WriteOff(batchID int, today string, req models.BatchWriteOffRequest) (*models.StockMovement, error)
+	WriteOffExpired(today string, req models.BatchWriteOffRequest) ([]models.StockMovement, error)
+}
+
+type productBatchRepository struct {
+	db *sql.DB
+}
+
+func NewProductBatchRepository(db *sql.DB) *productBatchRepository {
+	return &productBatchRepository{db: db}
+}
+
+const productBatchColumns = `
+	b.id, b.product_id, p.name, b.batch_number, b.expires_at, b.quantity, b.initial_quantity, b.goods_receipt_id,
+	b.received_at, b.quantity * p.cost_price
+`
+
+func (r *productBatchRepository) fetch(query string, args ...any) ([]models.ProductBatch, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := make([]models.ProductBatch, 0)
+	for rows.Next() {
+		var b models.ProductBatch
+		var expiresAt sql.NullTime
+		var goodsReceiptID sql.NullInt64
+		err := rows.Scan(&b.ID, &b.ProductID, &b.ProductName, &b.BatchNumber, &expiresAt, &b.Quantity, &b.InitialQuantity,
+			&goodsReceiptID, &b.ReceivedAt, &b.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.ExpiresAt = dateString(expiresAt)
+		if goodsReceiptID.Valid {
+			id := int(goodsReceiptID.Int64)
+			b.GoodsReceiptID = &id
+		}
+		batches = append(batches, b)
+	}
+	return batches, rows.Err()
+}
+
+// FetchByProduct mengambil batch produk dalam urutan FEFO.
+func (r *productBatchRepository) FetchByProduct(productID int, includeEmpty bool) ([]models.ProductBatch, error) {
+	return r.fetch(`
+		SELECT `+productBatchColumns+`
+		FROM product_batches b
+		JOIN products p ON p.id = b.product_id
+		WHERE b.product_id = $1 AND ($2 OR b.quantity > 0)
+		ORDER BY b.expires_at NULLS LAST, b.id
+	`, productID, includeEmpty)
+}
+
+// FetchExpiring mengambil batch yang masih ada stoknya dan kadaluarsa paling lambat tanggal until,
+// termasuk yang sudah kadaluarsa.
+func (r *productBatchRepository) FetchExpiring(until string) ([]models.ProductBatch, error) {
+	return r.fetch(`
+		SELECT `+productBatchColumns+`
+		FROM product_batches b
+		JOIN products p ON p.id = b.product_id
+		WHERE b.quantity > 0 AND b.expires_at <= $1::date AND p.deleted_at IS NULL
+		ORDER BY b.expires_at, b.id
+	`, until)
+}
+
+// WriteOff menghapus sisa stok satu batch yang sudah kadaluarsa (sebelum tanggal today).
+func (r *productBatchRepository) WriteOff(batchID int, today string, req models.BatchWriteOffRequest) (*models.StockMovement, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var productID int
+	err = tx.QueryRow("SELECT product_id FROM product_batches WHERE id = $1", batchID).Scan(&productID)
+	if err == sql.ErrNoRows {
+		return nil, ErrBatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := writeOffBatch(tx, productID, batchID, today, req)
+	if err != nil {
+		return nil, err
+	}
+	return movement, tx.Commit()
+}
+
+// WriteOffExpired menghapus sisa stok semua batch yang sudah kadaluarsa dalam satu transaksi.
+func (r *productBatchRepository) WriteOffExpired(today string, req models.BatchWriteOffRequest) ([]models.StockMovement, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Urut product ID agar urutan kunci produk sama dengan checkout
+	rows, err := tx.Query(`
+		SELECT product_id, id FROM product_batches
+		WHERE quantity > 0 AND expires_at < $1::date
+		ORDER BY product_id, id
+	`, today)
+	if err != nil {
+		return nil, err
+	}
+	var batches [][2]int
+	for rows.Next() {
+		var b [2]int
+		if err := rows.Scan(&b[0], &b[1]); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		batches = append(batches, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	movements := make([]models.StockMovement, 0, len(batches))
+	for _, b := range batches {
+		movement, err := writeOffBatch(tx, b[0], b[1], today, req)
+		if errors.Is(err, ErrBatchEmpty) {
+			continue // Sudah habis oleh transaksi lain sejak daftar diambil
+		}
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, *movement)
+	}
+	return movements, tx.Commit()
+}
+
+func writeOffBatch(tx *sql.Tx, productID, batchID int, today string, req models.BatchWriteOffRequest) (*models.StockMovement, error) {
+	// Kunci produk lebih dulu, sama seperti changeStock, baru baca sisa batch
+	if _, err := tx.Exec("SELECT 1 FROM products WHERE id = $1 FOR UPDATE", productID); err != nil {
+		return nil, err
+	}
+
+	var quantity int
+	var expired bool
+	err := tx.QueryRow(`
+		SELECT quantity, COALESCE(expires_at < $2::date, FALSE) FROM product_batches WHERE id = $1
+	`, batchID, today).Scan(&quantity, &expired)
+	if err != nil {
+		return nil, err
+	}
+	if !expired {
+		return nil, ErrBatchNotExpired
+	}
+	if quantity == 0 {
+		return nil, ErrBatchEmpty
+	}
+
+	movement := models.StockMovement{
+		ProductID: productID,
+		Type:      models.StockMovementAdjustment,
+		Quantity:  -quantity,
+		Reason:    models.StockReasonExpired,
+		Note:      req.Note,
+		User:      req.User,
+		BatchID:   &batchID,
+	}
+	if err := changeStock(tx, &movement); err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
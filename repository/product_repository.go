@@ -2,17 +2,79 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"kasir-api/barcode"
 	"kasir-api/models"
+	"math"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrProductNotFound = errors.New("product not found")
+	ErrBarcodeExists   = errors.New("barcode already used by another product")
+	ErrBarcodeNotFound = errors.New("barcode not found")
+	ErrSKUExists       = errors.New("sku already used by another product")
+	ErrVariantExists   = errors.New("variant with the same option values already exists")
+	ErrVariantParent   = errors.New("variants can only be added to a product without stock that is not itself a variant")
+	ErrVariantRequired = errors.New("product has variants, use variant_id")
+	ErrVariantMismatch = errors.New("variant does not belong to product")
+	ErrUnitNotFound    = errors.New("unit is not sellable for this product")
+	ErrInvalidQuantity = errors.New("invalid quantity for unit")
+	ErrInvalidRecipe   = errors.New("invalid recipe")
+	ErrNoOwnStock      = errors.New("composite products and variant parents do not hold stock")
 )
 
+// hasActiveVariants bernilai true jika produk p adalah induk yang punya varian aktif; induk seperti ini tidak bisa dijual langsung.
+const hasActiveVariants = `EXISTS (SELECT 1 FROM products pv WHERE pv.parent_id = p.id AND pv.deleted_at IS NULL)`
+
+// isComposite bernilai true jika produk p punya resep; stoknya dihitung dari komponen.
+const isComposite = `EXISTS (SELECT 1 FROM product_components WHERE product_id = p.id)`
+
+// checkOwnStock menolak produk yang stoknya harus tetap 0: produk komposit (stok dari komponen) dan induk
+// yang punya varian (stok ada di setiap varian).
+func checkOwnStock(q querier, productID int) error {
+	var name string
+	var composite, variants bool
+	err := q.QueryRow(`
+		SELECT p.name, `+isComposite+`, `+hasActiveVariants+`
+		FROM products p
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`, productID).Scan(&name, &composite, &variants)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: id %d", ErrProductNotFound, productID)
+	}
+	if err != nil {
+		return err
+	}
+	if composite || variants {
+		return fmt.Errorf("%w: %s", ErrNoOwnStock, name)
+	}
+	return nil
+}
+
 type ProductRepository interface {
 	FetchAll(name string) ([]models.Product, error)
 	FetchByID(id int) (models.Product, error)
+	FetchByCode(code string) (models.Product, error)
 	Store(product *models.Product) error
-	Update(product *models.Product) error
+	Update(product *models.Product, setStock bool) error
 	Delete(id int) error
+	FetchLowStock() ([]models.LowStockProduct, error)
+	FetchOptions(productID int) ([]models.ProductOption, error)
+	SetOptions(productID int, options []models.ProductOption) error
+	FetchVariants(parentID int) ([]models.Product, error)
+	CreateVariant(parentID int, variant *models.Product) error
+	FetchUnits(productID int) ([]models.ProductUnit, error)
+	SetUnits(productID int, units []models.ProductUnit) error
+	FetchComponents(productID int) ([]models.ProductComponent, error)
+	SetComponents(productID int, components []models.ProductComponent) error
+	AddBarcode(b *models.ProductBarcode) error
+	DeleteBarcode(productID int, code string) error
+	GenerateInternalBarcodes(productID int) ([]models.ProductBarcode, error)
 }
 
 type productRepository struct {
@@ -23,21 +85,52 @@ func NewProductRepository(db *sql.DB) *productRepository {
 	return &productRepository{db: db}
 }
 
-func (r *productRepository) FetchAll(name string) ([]models.Product, error) {
-	query := `
-		SELECT p.id, p.name, p.price, p.stock, p.category_id, p.created_at, p.updated_at,
-		       c.id, c.name
-		FROM products p
-		JOIN categories c ON p.category_id = c.id
-		WHERE p.deleted_at IS NULL
-	`
+const productColumns = `
+	p.id, p.name, COALESCE(p.sku, ''), p.price, p.cost_price, p.stock, p.category_id, p.tax_category_id, p.tax_exempt,
+	p.reorder_point, p.reorder_quantity, p.parent_id, p.option_values, p.base_unit, p.created_at, p.updated_at,
+	c.id, c.name, ` + compositeAvailable + `
+`
 
-	var args []interface{}
-	if name != "" {
-		query += " AND p.name ILIKE $1"
-		args = append(args, "%"+name+"%")
+// compositeAvailable adalah jumlah produk komposit p yang bisa dibuat dari stok komponennya,
+// NULL jika p bukan produk komposit. Komponen yang sudah dihapus membuat produk tidak tersedia.
+const compositeAvailable = `(
+	SELECT GREATEST(MIN(CASE WHEN cp.deleted_at IS NULL THEN cp.stock / pc.quantity ELSE 0 END), 0)
+	FROM product_components pc
+	JOIN products cp ON cp.id = pc.component_id
+	WHERE pc.product_id = p.id
+)`
+
+func scanProduct(row rowScanner) (models.Product, error) {
+	var p models.Product
+	var c models.Category
+	var parentID, available sql.NullInt64
+	var optionValues []byte
+
+	err := row.Scan(
+		&p.ID, &p.Name, &p.SKU, &p.Price, &p.CostPrice, &p.Stock, &p.CategoryID, &p.TaxCategoryID, &p.TaxExempt,
+		&p.ReorderPoint, &p.ReorderQuantity, &parentID, &optionValues, &p.BaseUnit, &p.CreatedAt, &p.UpdatedAt,
+		&c.ID, &c.Name, &available,
+	)
+	if err != nil {
+		return p, err
 	}
+	if parentID.Valid {
+		id := int(parentID.Int64)
+		p.ParentID = &id
+	}
+	if err := json.Unmarshal(optionValues, &p.OptionValues); err != nil {
+		return p, err
+	}
+	if available.Valid {
+		n := int(available.Int64)
+		p.Composite = true
+		p.Available = &n
+	}
+	p.Category = &c
+	return p, nil
+}
 
+func (r *productRepository) fetch(query string, args ...interface{}) ([]models.Product, error) {
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -46,57 +139,162 @@ func (r *productRepository) FetchAll(name string) ([]models.Product, error) {
 
 	var products []models.Product
 	for rows.Next() {
-		var p models.Product
-		var c models.Category
-
-		err := rows.Scan(
-			&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CreatedAt, &p.UpdatedAt,
-			&c.ID, &c.Name,
-		)
+		p, err := scanProduct(rows)
 		if err != nil {
 			return nil, err
 		}
-		p.Category = &c
 		products = append(products, p)
 	}
-	return products, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return products, r.loadBarcodes(products)
+}
+
+// loadBarcodes mengisi Barcodes setiap produk.
+func (r *productRepository) loadBarcodes(products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(products))
+	index := make(map[int]int, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+		index[products[i].ID] = i
+		products[i].Barcodes = make([]models.ProductBarcode, 0)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT product_id, code, type, created_at
+		FROM product_barcodes
+		WHERE product_id = ANY($1)
+		ORDER BY id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b models.ProductBarcode
+		if err := rows.Scan(&b.ProductID, &b.Code, &b.Type, &b.CreatedAt); err != nil {
+			return err
+		}
+		p := &products[index[b.ProductID]]
+		p.Barcodes = append(p.Barcodes, b)
+	}
+	return rows.Err()
+}
+
+// FetchAll mencari produk berdasarkan nama (ILIKE), atau SKU / barcode yang sama persis.
+func (r *productRepository) FetchAll(name string) ([]models.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		JOIN categories c ON p.category_id = c.id
+		WHERE p.deleted_at IS NULL
+	`
+
+	var args []interface{}
+	if name != "" {
+		query += ` AND (p.name ILIKE $1 OR p.sku = $2
+			OR EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.code = $2))`
+		args = append(args, "%"+name+"%", name)
+	}
+	return r.fetch(query, args...)
 }
 
 func (r *productRepository) FetchByID(id int) (models.Product, error) {
 	query := `
-		SELECT p.id, p.name, p.price, p.stock, p.category_id, p.created_at, p.updated_at,
-		       c.id, c.name
+		SELECT ` + productColumns + `
 		FROM products p
 		JOIN categories c ON p.category_id = c.id
 		WHERE p.id = $1 AND p.deleted_at IS NULL
 	`
-	var p models.Product
-	var c models.Category
+	return r.fetchOne(query, id)
+}
 
-	err := r.db.QueryRow(query, id).Scan(
-		&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CreatedAt, &p.UpdatedAt,
-		&c.ID, &c.Name,
-	)
+// FetchByCode mencari produk dari barcode, atau dari SKU jika tidak ada barcode yang cocok.
+func (r *productRepository) FetchByCode(code string) (models.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		JOIN categories c ON p.category_id = c.id
+		WHERE p.deleted_at IS NULL
+		  AND (p.id = (SELECT product_id FROM product_barcodes WHERE code = $1) OR p.sku = $1)
+		ORDER BY p.sku = $1
+		LIMIT 1
+	`
+	p, err := r.fetchOne(query, code)
+	if errors.Is(err, ErrProductNotFound) {
+		return p, ErrBarcodeNotFound
+	}
+	return p, err
+}
 
+func (r *productRepository) fetchOne(query string, args ...interface{}) (models.Product, error) {
+	p, err := scanProduct(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return p, ErrProductNotFound
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return p, errors.New("product not found")
-		}
 		return p, err
 	}
-	p.Category = &c
-	return p, nil
+	products := []models.Product{p}
+	if err := r.loadBarcodes(products); err != nil {
+		return p, err
+	}
+	return products[0], nil
 }
 
 func (r *productRepository) Store(p *models.Product) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	p.ParentID = nil
+	p.OptionValues = nil
+	if err := insertProduct(tx, p); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertProduct menyimpan produk baru (atau varian jika p.ParentID diisi) beserta saldo awal stoknya di ledger.
+func insertProduct(tx *sql.Tx, p *models.Product) error {
 	query := `
-		INSERT INTO products (name, price, stock, category_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO products (name, sku, price, cost_price, stock, category_id, tax_category_id, tax_exempt, reorder_point, reorder_quantity,
+			parent_id, option_values, base_unit, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
+	optionValues, err := json.Marshal(p.OptionValues)
+	if err != nil {
+		return err
+	}
+	if p.OptionValues == nil {
+		optionValues = []byte("{}")
+	}
+
 	now := time.Now()
-	err := r.db.QueryRow(query, p.Name, p.Price, p.Stock, p.CategoryID, now, now).Scan(&p.ID)
+	err = tx.QueryRow(query, p.Name, p.SKU, p.Price, p.CostPrice, p.Stock, p.CategoryID, p.TaxCategoryID, p.TaxExempt, p.ReorderPoint, p.ReorderQuantity,
+		p.ParentID, string(optionValues), p.BaseUnit, now, now).Scan(&p.ID)
 	if err != nil {
+		return productWriteError(err)
+	}
+	for i := range p.Barcodes {
+		p.Barcodes[i].ProductID = p.ID
+		if err := insertBarcode(tx, &p.Barcodes[i]); err != nil {
+			return err
+		}
+	}
+
+	// Stok awal dicatat sebagai saldo awal ledger
+	opening := models.StockMovement{ProductID: p.ID, Type: models.StockMovementOpening, Quantity: p.Stock, StockAfter: p.Stock}
+	if err := recordStockMovement(tx, &opening); err != nil {
 		return err
 	}
 	p.CreatedAt = now
@@ -104,27 +302,517 @@ func (r *productRepository) Store(p *models.Product) error {
 	return nil
 }
 
-func (r *productRepository) Update(p *models.Product) error {
+// productWriteError mengubah pelanggaran unique index barcode dan SKU menjadi ErrBarcodeExists / ErrSKUExists.
+func productWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case "idx_product_barcodes_code":
+		return ErrBarcodeExists
+	case "idx_products_sku":
+		return ErrSKUExists
+	}
+	return err
+}
+
+func insertBarcode(tx *sql.Tx, b *models.ProductBarcode) error {
+	err := tx.QueryRow("INSERT INTO product_barcodes (product_id, code, type) VALUES ($1, $2, $3) RETURNING created_at",
+		b.ProductID, b.Code, b.Type).Scan(&b.CreatedAt)
+	return productWriteError(err)
+}
+
+// Update mengubah data produk. Stok hanya diganti jika setStock true (dicatat sebagai penyesuaian di ledger);
+// selain itu p.Stock diisi ulang dengan stok terkini dari database.
+func (r *productRepository) Update(p *models.Product, setStock bool) error {
 	query := `
 		UPDATE products 
-		SET name = $1, price = $2, stock = $3, category_id = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at IS NULL
+		SET name = $1, sku = NULLIF($2, ''), price = $3, cost_price = $4, category_id = $5,
+		    tax_category_id = $6, tax_exempt = $7, reorder_point = $8, reorder_quantity = $9, base_unit = $10, updated_at = $11
+		WHERE id = $12 AND deleted_at IS NULL
 	`
-	p.UpdatedAt = time.Now()
-	res, err := r.db.Exec(query, p.Name, p.Price, p.Stock, p.CategoryID, p.UpdatedAt, p.ID)
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, _ := res.RowsAffected()
-	if rowsAffected == 0 {
+	// Kunci stok saat ini agar selisihnya bisa dicatat di ledger
+	var currentStock int
+	err = tx.QueryRow("SELECT stock FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", p.ID).Scan(&currentStock)
+	if err == sql.ErrNoRows {
 		return errors.New("product not found or no change")
 	}
-	return nil
+	if err != nil {
+		return err
+	}
+
+	p.UpdatedAt = time.Now()
+	_, err = tx.Exec(query, p.Name, p.SKU, p.Price, p.CostPrice, p.CategoryID, p.TaxCategoryID, p.TaxExempt, p.ReorderPoint, p.ReorderQuantity, p.BaseUnit, p.UpdatedAt, p.ID)
+	if err != nil {
+		return productWriteError(err)
+	}
+
+	if !setStock {
+		p.Stock = currentStock
+		return tx.Commit()
+	}
+	if delta := p.Stock - currentStock; delta != 0 {
+		if err := checkOwnStock(tx, p.ID); err != nil {
+			return err
+		}
+		movement := models.StockMovement{
+			ProductID: p.ID,
+			Type:      models.StockMovementAdjustment,
+			Quantity:  delta,
+			Reason:    models.StockReasonCorrection,
+			Note:      "product update",
+		}
+		if err := changeStock(tx, &movement); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (r *productRepository) Delete(id int) error {
-	query := `UPDATE products SET deleted_at = $1 WHERE id = $2`
-	_, err := r.db.Exec(query, time.Now(), id)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Varian ikut terhapus bersama produk induknya, barcodenya dilepas agar bisa dipakai produk lain
+	_, err = tx.Exec("DELETE FROM product_barcodes WHERE product_id IN (SELECT id FROM products WHERE id = $1 OR parent_id = $1)", id)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE products SET deleted_at = $1 WHERE (id = $2 OR parent_id = $2) AND deleted_at IS NULL`
+	if _, err := tx.Exec(query, time.Now(), id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FetchLowStock mengambil produk yang stoknya sudah mencapai reorder point, yang paling kritis lebih dulu.
+// Produk dengan reorder_point 0 tidak dipantau.
+func (r *productRepository) FetchLowStock() ([]models.LowStockProduct, error) {
+	query := `
+		SELECT id, name, COALESCE(sku, ''), stock, reorder_point, reorder_quantity
+		FROM products
+		WHERE deleted_at IS NULL AND reorder_point > 0 AND stock <= reorder_point
+		ORDER BY stock - reorder_point, id
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.LowStockProduct, 0)
+	for rows.Next() {
+		var p models.LowStockProduct
+		if err := rows.Scan(&p.ProductID, &p.Name, &p.SKU, &p.Stock, &p.ReorderPoint, &p.ReorderQuantity); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, rows.Err()
+}
+
+func (r *productRepository) FetchOptions(productID int) ([]models.ProductOption, error) {
+	rows, err := r.db.Query(`SELECT name, "values" FROM product_options WHERE product_id = $1 ORDER BY position, id`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options := make([]models.ProductOption, 0)
+	for rows.Next() {
+		var o models.ProductOption
+		var values []byte
+		if err := rows.Scan(&o.Name, &values); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(values, &o.Values); err != nil {
+			return nil, err
+		}
+		options = append(options, o)
+	}
+	return options, rows.Err()
+}
+
+// SetOptions mengganti seluruh jenis opsi produk, urutannya mengikuti urutan pada slice.
+func (r *productRepository) SetOptions(productID int, options []models.ProductOption) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockVariantParent(tx, productID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM product_options WHERE product_id = $1", productID); err != nil {
+		return err
+	}
+	for i, o := range options {
+		values, err := json.Marshal(o.Values)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO product_options (product_id, name, "values", position) VALUES ($1, $2, $3, $4)`,
+			productID, o.Name, string(values), i)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *productRepository) FetchVariants(parentID int) ([]models.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		JOIN categories c ON p.category_id = c.id
+		WHERE p.parent_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.id
+	`
+	variants, err := r.fetch(query, parentID)
+	if variants == nil && err == nil {
+		variants = make([]models.Product, 0)
+	}
+	return variants, err
+}
+
+// CreateVariant menyimpan varian sebagai produk anak. Kategori, pajak dan reorder point mengikuti induk.
+// Induk tidak boleh punya stok sendiri karena setelah punya varian hanya variannya yang bisa dijual.
+func (r *productRepository) CreateVariant(parentID int, v *models.Product) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockVariantParent(tx, parentID); err != nil {
+		return err
+	}
+
+	var stock int
+	err = tx.QueryRow(`
+		SELECT stock, category_id, tax_category_id, tax_exempt, reorder_point, reorder_quantity, base_unit
+		FROM products WHERE id = $1
+	`, parentID).Scan(&stock, &v.CategoryID, &v.TaxCategoryID, &v.TaxExempt, &v.ReorderPoint, &v.ReorderQuantity, &v.BaseUnit)
+	if err != nil {
+		return err
+	}
+	if stock != 0 {
+		return ErrVariantParent
+	}
+
+	optionValues, err := json.Marshal(v.OptionValues)
+	if err != nil {
+		return err
+	}
+	var exists bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM products WHERE parent_id = $1 AND option_values = $2::jsonb AND deleted_at IS NULL)
+	`, parentID, string(optionValues)).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrVariantExists
+	}
+
+	v.ParentID = &parentID
+	if err := insertProduct(tx, v); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockVariantParent mengunci produk induk agar opsi dan variannya tidak diubah bersamaan.
+func lockVariantParent(tx *sql.Tx, productID int) error {
+	var parentID sql.NullInt64
+	err := tx.QueryRow("SELECT parent_id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", productID).Scan(&parentID)
+	if err == sql.ErrNoRows {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if parentID.Valid {
+		return ErrVariantParent
+	}
+	return nil
+}
+
+func (r *productRepository) FetchUnits(productID int) ([]models.ProductUnit, error) {
+	rows, err := r.db.Query(`
+		SELECT unit, factor, price, sellable, fractional
+		FROM product_units
+		WHERE product_id = $1
+		ORDER BY factor, unit
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	units := make([]models.ProductUnit, 0)
+	for rows.Next() {
+		var u models.ProductUnit
+		if err := rows.Scan(&u.Unit, &u.Factor, &u.Price, &u.Sellable, &u.Fractional); err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+// SetUnits mengganti seluruh satuan tambahan produk. Keranjang yang memakai satuan yang dihapus
+// akan ditolak saat checkout.
+func (r *productRepository) SetUnits(productID int, units []models.ProductUnit) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(tx, productID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM product_units WHERE product_id = $1", productID); err != nil {
+		return err
+	}
+	for _, u := range units {
+		_, err = tx.Exec(`
+			INSERT INTO product_units (product_id, unit, factor, price, sellable, fractional)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, productID, u.Unit, u.Factor, u.Price, u.Sellable, u.Fractional)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// saleUnit adalah satuan yang dipakai saat menjual produk beserta harganya per satuan tersebut.
+type saleUnit struct {
+	name       string
+	factor     int
+	price      models.Money
+	fractional bool
+}
+
+// lookupSaleUnit mencari satuan jual produk. Satuan kosong atau sama dengan satuan dasar memakai harga produk.
+func lookupSaleUnit(q querier, productID int, unit, baseUnit string, basePrice models.Money) (saleUnit, error) {
+	if unit == "" || unit == baseUnit {
+		return saleUnit{name: baseUnit, factor: 1, price: basePrice}, nil
+	}
+
+	u := saleUnit{name: unit}
+	err := q.QueryRow("SELECT factor, price, fractional FROM product_units WHERE product_id = $1 AND unit = $2 AND sellable", productID, unit).
+		Scan(&u.factor, &u.price, &u.fractional)
+	if err == sql.ErrNoRows {
+		return u, fmt.Errorf("%w: %s", ErrUnitNotFound, unit)
+	}
+	return u, err
+}
+
+// baseQuantity mengubah quantity dalam satuan u menjadi satuan dasar. Hasilnya harus bilangan bulat positif,
+// jadi 0.25 kg bisa dijual jika satuan dasarnya gram tetapi tidak jika satuan dasarnya kg.
+func (u saleUnit) baseQuantity(quantity float64) (int, error) {
+	if !u.fractional && quantity != math.Trunc(quantity) {
+		return 0, fmt.Errorf("%w: %s must be sold in whole numbers", ErrInvalidQuantity, u.name)
+	}
+	base := quantity * float64(u.factor)
+	rounded := math.Round(base)
+	if rounded <= 0 || math.Abs(base-rounded) > 1e-6 {
+		return 0, fmt.Errorf("%w: %v %s is not a whole number of base units", ErrInvalidQuantity, quantity, u.name)
+	}
+	return int(rounded), nil
+}
+
+func (r *productRepository) FetchComponents(productID int) ([]models.ProductComponent, error) {
+	rows, err := r.db.Query(`
+		SELECT pc.component_id, cp.name, cp.base_unit, pc.quantity, cp.stock
+		FROM product_components pc
+		JOIN products cp ON cp.id = pc.component_id
+		WHERE pc.product_id = $1
+		ORDER BY pc.id
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := make([]models.ProductComponent, 0)
+	for rows.Next() {
+		var c models.ProductComponent
+		if err := rows.Scan(&c.ComponentID, &c.Name, &c.BaseUnit, &c.Quantity, &c.Stock); err != nil {
+			return nil, err
+		}
+		components = append(components, c)
+	}
+	return components, rows.Err()
+}
+
+// SetComponents mengganti resep produk; slice kosong menghapus resep sehingga produk kembali memakai stoknya sendiri.
+// Resep tidak boleh bertingkat: komponen bukan produk komposit dan produk komposit bukan komponen produk lain.
+func (r *productRepository) SetComponents(productID int, components []models.ProductComponent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var stock int
+	var variants, usedAsComponent bool
+	err = tx.QueryRow(`
+		SELECT p.stock, `+hasActiveVariants+`, EXISTS (SELECT 1 FROM product_components WHERE component_id = p.id)
+		FROM products p
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+		FOR UPDATE
+	`, productID).Scan(&stock, &variants, &usedAsComponent)
+	if err == sql.ErrNoRows {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(components) > 0 {
+		switch {
+		case variants:
+			return fmt.Errorf("%w: product has variants, set the recipe on each variant", ErrInvalidRecipe)
+		case usedAsComponent:
+			return fmt.Errorf("%w: product is a component of another recipe", ErrInvalidRecipe)
+		case stock != 0:
+			return fmt.Errorf("%w: composite product must have stock 0, its stock comes from the components", ErrInvalidRecipe)
+		}
+	}
+
+	for _, c := range components {
+		var name string
+		var composite, variants bool
+		err := tx.QueryRow(`
+			SELECT p.name, `+isComposite+`, `+hasActiveVariants+`
+			FROM products p
+			WHERE p.id = $1 AND p.deleted_at IS NULL
+		`, c.ComponentID).Scan(&name, &composite, &variants)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: component id %d", ErrProductNotFound, c.ComponentID)
+		}
+		if err != nil {
+			return err
+		}
+		if composite || variants {
+			return fmt.Errorf("%w: component %s must not be a composite product or have variants", ErrInvalidRecipe, name)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM product_components WHERE product_id = $1", productID); err != nil {
+		return err
+	}
+	for _, c := range components {
+		_, err = tx.Exec("INSERT INTO product_components (product_id, component_id, quantity) VALUES ($1, $2, $3)",
+			productID, c.ComponentID, c.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *productRepository) AddBarcode(b *models.ProductBarcode) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(tx, b.ProductID); err != nil {
+		return err
+	}
+	if err := insertBarcode(tx, b); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *productRepository) DeleteBarcode(productID int, code string) error {
+	res, err := r.db.Exec("DELETE FROM product_barcodes WHERE product_id = $1 AND code = $2", productID, code)
+	if err != nil {
+		return err
+	}
+	if deleted, _ := res.RowsAffected(); deleted == 0 {
+		return ErrBarcodeNotFound
+	}
+	return nil
+}
+
+// GenerateInternalBarcodes membuat barcode internal (EAN-13 prefix 20) untuk produk yang belum punya barcode.
+// productID 0 berarti semua produk aktif tanpa barcode, kecuali induk varian yang tidak dijual langsung.
+func (r *productRepository) GenerateInternalBarcodes(productID int) ([]models.ProductBarcode, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT p.id FROM products p
+		WHERE p.deleted_at IS NULL AND NOT ` + hasActiveVariants + `
+		  AND NOT EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id)
+	`
+	var args []interface{}
+	if productID != 0 {
+		query += " AND p.id = $1"
+		args = append(args, productID)
+	}
+	query += " ORDER BY p.id FOR UPDATE"
+
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	barcodes := make([]models.ProductBarcode, 0, len(ids))
+	for _, id := range ids {
+		var seq int64
+		if err := tx.QueryRow("SELECT nextval('internal_barcode_seq')").Scan(&seq); err != nil {
+			return nil, err
+		}
+		b := models.ProductBarcode{ProductID: id, Code: barcode.Internal(seq), Type: models.BarcodeTypeInternal}
+		if err := insertBarcode(tx, &b); err != nil {
+			return nil, err
+		}
+		barcodes = append(barcodes, b)
+	}
+	return barcodes, tx.Commit()
+}
+
+func lockProduct(tx *sql.Tx, productID int) error {
+	var id int
+	err := tx.QueryRow("SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", productID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return ErrProductNotFound
+	}
 	return err
 }
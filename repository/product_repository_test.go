@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+// createTestComposite membuat produk komposit berstok 0 dengan satu komponen.
+func createTestComposite(t *testing.T, db *sql.DB) (composite, component models.Product) {
+	t.Helper()
+	component = createTestProduct(t, db, 10)
+	composite = createTestProduct(t, db, 0)
+	err := NewProductRepository(db).SetComponents(composite.ID, []models.ProductComponent{{ComponentID: component.ID, Quantity: 2}})
+	if err != nil {
+		t.Fatalf("set recipe: %v", err)
+	}
+	return composite, component
+}
+
+func TestProductUpdateSetStockRejectsComposite(t *testing.T) {
+	db := testDB(t)
+	composite, _ := createTestComposite(t, db)
+	repo := NewProductRepository(db)
+
+	composite.Stock = 5
+	if err := repo.Update(&composite, true); !errors.Is(err, ErrNoOwnStock) {
+		t.Fatalf("Update with set_stock error = %v, want ErrNoOwnStock", err)
+	}
+
+	// Tanpa set_stock data produk tetap bisa diubah
+	composite.Name += " (baru)"
+	if err := repo.Update(&composite, false); err != nil {
+		t.Fatalf("Update without set_stock: %v", err)
+	}
+	if composite.Stock != 0 {
+		t.Errorf("stock = %d, want 0", composite.Stock)
+	}
+}
+
+func TestSaleUnitBaseQuantity(t *testing.T) {
+	pcs := saleUnit{name: "pcs", factor: 1}
+	dus := saleUnit{name: "dus", factor: 24}
+	kg := saleUnit{name: "kg", factor: 1000, fractional: true} // Satuan dasar gram
+	tests := []struct {
+		name     string
+		unit     saleUnit
+		quantity float64
+		want     int
+		wantErr  error
+	}{
+		{"base unit", pcs, 3, 3, nil},
+		{"pack conversion", dus, 2, 48, nil},
+		{"fraction of whole unit", pcs, 1.5, 0, ErrInvalidQuantity},
+		{"fraction of pack", dus, 0.5, 0, ErrInvalidQuantity},
+		{"zero", pcs, 0, 0, ErrInvalidQuantity},
+		{"negative", dus, -1, 0, ErrInvalidQuantity},
+		{"fractional kg to gram", kg, 0.25, 250, nil},
+		{"float error rounds to base unit", kg, 0.1 + 0.2, 300, nil},
+		{"whole kg", kg, 2, 2000, nil},
+		{"less than one gram", kg, 0.0004, 0, ErrInvalidQuantity},
+		{"not a whole gram", kg, 1.2345, 0, ErrInvalidQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.unit.baseQuantity(tt.quantity)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("baseQuantity(%v %s) = %d, %v, want %d, %v", tt.quantity, tt.unit.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLookupSaleUnit(t *testing.T) {
+	db := testDB(t)
+	product := createTestProduct(t, db, 100)
+	err := NewProductRepository(db).SetUnits(product.ID, []models.ProductUnit{
+		{Unit: "dus", Factor: 24, Price: models.Rupiah(22000), Sellable: true},
+		{Unit: "karton", Factor: 96, Price: models.Rupiah(85000)},
+	})
+	if err != nil {
+		t.Fatalf("set units: %v", err)
+	}
+
+	tests := []struct {
+		unit       string
+		wantFactor int
+		wantPrice  int64
+		wantErr    error
+	}{
+		{"", 1, product.Price.Amount, nil},
+		{product.BaseUnit, 1, product.Price.Amount, nil},
+		{"dus", 24, 22000, nil},
+		{"karton", 0, 0, ErrUnitNotFound}, // Satuan beli saja
+		{"lusin", 0, 0, ErrUnitNotFound},
+	}
+	for _, tt := range tests {
+		u, err := lookupSaleUnit(db, product.ID, tt.unit, product.BaseUnit, product.Price)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("lookupSaleUnit(%q) error = %v, want %v", tt.unit, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr == nil && (u.factor != tt.wantFactor || u.price.Amount != tt.wantPrice) {
+			t.Errorf("lookupSaleUnit(%q) = factor %d price %d, want %d and %d", tt.unit, u.factor, u.price.Amount, tt.wantFactor, tt.wantPrice)
+		}
+	}
+}
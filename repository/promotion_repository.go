@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"kasir-api/models"
+	"time"
+)
+
+var ErrPromotionNotFound = errors.New("promotion not found")
+
+type PromotionRepository interface {
+	FetchAll() ([]models.Promotion, error)
+	FetchByID(id int) (models.Promotion, error)
+	FetchActive(at time.Time) ([]models.Promotion, error)
+	Store(promotion *models.Promotion) error
+	Update(promotion *models.Promotion) error
+	Delete(id int) error
+}
+
+type promotionRepository struct {
+	db *sql.DB
+}
+
+func NewPromotionRepository(db *sql.DB) *promotionRepository {
+	return &promotionRepository{db: db}
+}
+
+const promotionColumns = `
+	id, name, type, active, product_id, category_id, discount_percent, discount_amount,
+	buy_quantity, get_quantity, bundle_items, bundle_price, min_spend,
+	starts_at, ends_at, start_time, end_time, days_of_week, created_at, updated_at
+`
+
+func (r *promotionRepository) FetchAll() ([]models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE deleted_at IS NULL ORDER BY id`
+	return r.fetch(query)
+}
+
+// FetchActive mengambil promosi aktif yang periodenya mencakup waktu at.
+// Batas jam (happy hour) dan hari dicek di service karena bergantung zona waktu toko.
+func (r *promotionRepository) FetchActive(at time.Time) ([]models.Promotion, error) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE deleted_at IS NULL AND active
+		  AND (starts_at IS NULL OR starts_at <= $1)
+		  AND (ends_at IS NULL OR ends_at > $1)
+		ORDER BY id
+	`
+	return r.fetch(query, at)
+}
+
+func (r *promotionRepository) fetch(query string, args ...interface{}) ([]models.Promotion, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]models.Promotion, 0)
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, rows.Err()
+}
+
+func (r *promotionRepository) FetchByID(id int) (models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE id = $1 AND deleted_at IS NULL`
+
+	p, err := scanPromotion(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return p, ErrPromotionNotFound
+		}
+		return p, err
+	}
+	return p, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotion(row rowScanner) (models.Promotion, error) {
+	var p models.Promotion
+	var productID, categoryID sql.NullInt64
+	var startsAt, endsAt sql.NullTime
+	var bundleItems, daysOfWeek []byte
+
+	err := row.Scan(
+		&p.ID, &p.Name, &p.Type, &p.Active, &productID, &categoryID, &p.DiscountPercent, &p.DiscountAmount,
+		&p.BuyQuantity, &p.GetQuantity, &bundleItems, &p.BundlePrice, &p.MinSpend,
+		&startsAt, &endsAt, &p.StartTime, &p.EndTime, &daysOfWeek, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return p, err
+	}
+
+	if productID.Valid {
+		id := int(productID.Int64)
+		p.ProductID = &id
+	}
+	if categoryID.Valid {
+		id := int(categoryID.Int64)
+		p.CategoryID = &id
+	}
+	if startsAt.Valid {
+		p.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	if err := json.Unmarshal(bundleItems, &p.BundleItems); err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(daysOfWeek, &p.DaysOfWeek); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (r *promotionRepository) Store(p *models.Promotion) error {
+	bundleItems, daysOfWeek, err := promotionJSON(p)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO promotions (name, type, active, product_id, category_id, discount_percent, discount_amount,
+			buy_quantity, get_quantity, bundle_items, bundle_price, min_spend,
+			starts_at, ends_at, start_time, end_time, days_of_week, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING id
+	`
+	now := time.Now()
+	err = r.db.QueryRow(query, p.Name, p.Type, p.Active, p.ProductID, p.CategoryID, p.DiscountPercent, p.DiscountAmount,
+		p.BuyQuantity, p.GetQuantity, bundleItems, p.BundlePrice, p.MinSpend,
+		p.StartsAt, p.EndsAt, p.StartTime, p.EndTime, daysOfWeek, now, now).Scan(&p.ID)
+	if err != nil {
+		return err
+	}
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	return nil
+}
+
+func (r *promotionRepository) Update(p *models.Promotion) error {
+	bundleItems, daysOfWeek, err := promotionJSON(p)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE promotions
+		SET name = $1, type = $2, active = $3, product_id = $4, category_id = $5, discount_percent = $6,
+			discount_amount = $7, buy_quantity = $8, get_quantity = $9, bundle_items = $10, bundle_price = $11,
+			min_spend = $12, starts_at = $13, ends_at = $14, start_time = $15, end_time = $16,
+			days_of_week = $17, updated_at = $18
+		WHERE id = $19 AND deleted_at IS NULL
+	`
+	p.UpdatedAt = time.Now()
+	res, err := r.db.Exec(query, p.Name, p.Type, p.Active, p.ProductID, p.CategoryID, p.DiscountPercent,
+		p.DiscountAmount, p.BuyQuantity, p.GetQuantity, bundleItems, p.BundlePrice,
+		p.MinSpend, p.StartsAt, p.EndsAt, p.StartTime, p.EndTime,
+		daysOfWeek, p.UpdatedAt, p.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+func promotionJSON(p *models.Promotion) (string, string, error) {
+	bundleItems := p.BundleItems
+	if bundleItems == nil {
+		bundleItems = []models.BundleItem{}
+	}
+	items, err := json.Marshal(bundleItems)
+	if err != nil {
+		return "", "", err
+	}
+
+	daysOfWeek := p.DaysOfWeek
+	if daysOfWeek == nil {
+		daysOfWeek = []int{}
+	}
+	days, err := json.Marshal(daysOfWeek)
+	if err != nil {
+		return "", "", err
+	}
+	return string(items), string(days), nil
+}
+
+func (r *promotionRepository) Delete(id int) error {
+	query := `UPDATE promotions SET deleted_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
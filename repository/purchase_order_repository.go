@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"sort"
+	"time"
+)
+
+var (
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrPurchaseOrderClosed   = errors.New("purchase order is already received or cancelled")
+	ErrProductNotInOrder     = errors.New("product is not in purchase order")
+	ErrReceiptExceedsOrder   = errors.New("received quantity exceeds remaining ordered quantity")
+)
+
+type PurchaseOrderRepository interface {
+	FetchAll(supplierID int, status string) ([]models.PurchaseOrder, error)
+	FetchByID(id int) (*models.PurchaseOrder, error)
+	Create(req models.CreatePurchaseOrderRequest) (int, error)
+	Cancel(id int) error
+	Receive(id int, req models.GoodsReceiptRequest) (int, error)
+	GetPurchaseReport(query models.ReportQuery, supplierID int) (models.PurchaseReport, error)
+}
+
+type purchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) *purchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+const purchaseOrderColumns = `
+	po.id, po.supplier_id, s.name, po.status, po.expected_date, po.note, po.created_by, po.created_at, po.updated_at
+`
+
+func scanPurchaseOrder(row rowScanner) (models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	var expectedDate sql.NullTime
+	err := row.Scan(&po.ID, &po.SupplierID, &po.SupplierName, &po.Status, &expectedDate, &po.Note, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt)
+	po.ExpectedDate = dateString(expectedDate)
+	return po, err
+}
+
+func (r *purchaseOrderRepository) FetchAll(supplierID int, status string) ([]models.PurchaseOrder, error) {
+	rows, err := r.db.Query(`
+		SELECT `+purchaseOrderColumns+`
+		FROM purchase_orders po
+		JOIN suppliers s ON po.supplier_id = s.id
+		WHERE ($1 = 0 OR po.supplier_id = $1) AND ($2 = '' OR po.status = $2)
+		ORDER BY po.id DESC
+	`, supplierID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]models.PurchaseOrder, 0)
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, po)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadPurchaseOrderLines(r.db, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *purchaseOrderRepository) FetchByID(id int) (*models.PurchaseOrder, error) {
+	po, err := scanPurchaseOrder(r.db.QueryRow(`
+		SELECT `+purchaseOrderColumns+`
+		FROM purchase_orders po
+		JOIN suppliers s ON po.supplier_id = s.id
+		WHERE po.id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	orders := []models.PurchaseOrder{po}
+	if err := loadPurchaseOrderLines(r.db, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// loadPurchaseOrderLines mengisi Lines, Receipts dan total biaya untuk beberapa purchase order sekaligus.
+func loadPurchaseOrderLines(q querier, orders []models.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(orders))
+	index := make(map[int]int, len(orders))
+	for i := range orders {
+		ids[i] = orders[i].ID
+		index[orders[i].ID] = i
+		orders[i].Lines = make([]models.PurchaseOrderLine, 0)
+		orders[i].Receipts = make([]models.GoodsReceipt, 0)
+		orders[i].TotalCost = models.Rupiah(0)
+		orders[i].ReceivedCost = models.Rupiah(0)
+	}
+
+	rows, err := q.Query(`
+		SELECT l.purchase_order_id, l.id, l.product_id, p.name, l.quantity, l.received_quantity, l.unit_cost
+		FROM purchase_order_lines l
+		JOIN products p ON l.product_id = p.id
+		WHERE l.purchase_order_id = ANY($1)
+		ORDER BY l.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int
+		var l models.PurchaseOrderLine
+		if err := rows.Scan(&orderID, &l.ID, &l.ProductID, &l.ProductName, &l.Quantity, &l.ReceivedQuantity, &l.UnitCost); err != nil {
+			return err
+		}
+		po := &orders[index[orderID]]
+		po.Lines = append(po.Lines, l)
+		po.TotalCost = po.TotalCost.Add(l.UnitCost.Mul(int64(l.Quantity)))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	receiptRows, err := q.Query(`
+		SELECT gr.id, gr.purchase_order_id, gr.note, gr.received_by, gr.received_at,
+		       grl.id, grl.purchase_order_line_id, grl.product_id, grl.quantity, grl.unit_cost, grl.batch_id
+		FROM goods_receipts gr
+		JOIN goods_receipt_lines grl ON grl.goods_receipt_id = gr.id
+		WHERE gr.purchase_order_id = ANY($1)
+		ORDER BY gr.id, grl.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer receiptRows.Close()
+
+	for receiptRows.Next() {
+		var gr models.GoodsReceipt
+		var l models.GoodsReceiptLine
+		var batchID sql.NullInt64
+		err := receiptRows.Scan(&gr.ID, &gr.PurchaseOrderID, &gr.Note, &gr.ReceivedBy, &gr.ReceivedAt,
+			&l.ID, &l.PurchaseOrderLineID, &l.ProductID, &l.Quantity, &l.UnitCost, &batchID)
+		if err != nil {
+			return err
+		}
+		if batchID.Valid {
+			id := int(batchID.Int64)
+			l.BatchID = &id
+		}
+		po := &orders[index[gr.PurchaseOrderID]]
+		if n := len(po.Receipts); n == 0 || po.Receipts[n-1].ID != gr.ID {
+			gr.TotalCost = models.Rupiah(0)
+			gr.Lines = make([]models.GoodsReceiptLine, 0)
+			po.Receipts = append(po.Receipts, gr)
+		}
+		receipt := &po.Receipts[len(po.Receipts)-1]
+		cost := l.UnitCost.Mul(int64(l.Quantity))
+		receipt.Lines = append(receipt.Lines, l)
+		receipt.TotalCost = receipt.TotalCost.Add(cost)
+		po.ReceivedCost = po.ReceivedCost.Add(cost)
+	}
+	return receiptRows.Err()
+}
+
+func (r *purchaseOrderRepository) Create(req models.CreatePurchaseOrderRequest) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var supplierExists bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM suppliers WHERE id = $1 AND deleted_at IS NULL)", req.SupplierID).Scan(&supplierExists)
+	if err != nil {
+		return 0, err
+	}
+	if !supplierExists {
+		return 0, ErrSupplierNotFound
+	}
+
+	var id int
+	now := time.Now()
+	err = tx.QueryRow(`
+		INSERT INTO purchase_orders (supplier_id, status, expected_date, note, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, req.SupplierID, models.PurchaseOrderStatusOpen, req.ExpectedDate, req.Note, req.CreatedBy, now, now).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, l := range req.Lines {
+		if err := checkOwnStock(tx, l.ProductID); err != nil {
+			return 0, err
+		}
+		res, err := tx.Exec(`
+			INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_cost)
+			SELECT $1, id, $3, $4 FROM products WHERE id = $2 AND deleted_at IS NULL
+		`, id, l.ProductID, l.Quantity, l.UnitCost)
+		if err != nil {
+			return 0, err
+		}
+		if inserted, _ := res.RowsAffected(); inserted == 0 {
+			return 0, fmt.Errorf("%w: id %d", ErrProductNotFound, l.ProductID)
+		}
+	}
+	return id, tx.Commit()
+}
+
+// Cancel menutup purchase order. Barang yang sudah diterima tetap tercatat; sisa pesanan tidak lagi bisa diterima.
+func (r *purchaseOrderRepository) Cancel(id int) error {
+	res, err := r.db.Exec(`
+		UPDATE purchase_orders SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status IN ($3, $4)
+	`, models.PurchaseOrderStatusCancelled, id, models.PurchaseOrderStatusOpen, models.PurchaseOrderStatusPartiallyReceived)
+	if err != nil {
+		return err
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		if _, err := r.FetchByID(id); err != nil {
+			return err
+		}
+		return ErrPurchaseOrderClosed
+	}
+	return nil
+}
+
+// Receive mencatat penerimaan barang: stok bertambah lewat ledger (purchase_receipt) dan harga pokok produk
+// diperbarui dengan rata-rata tertimbang antara stok lama dan barang yang baru diterima. Produk komposit dan
+// induk varian ditolak karena stoknya harus tetap 0.
+func (r *purchaseOrderRepository) Receive(id int, req models.GoodsReceiptRequest) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return 0, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if status != models.PurchaseOrderStatusOpen && status != models.PurchaseOrderStatusPartiallyReceived {
+		return 0, ErrPurchaseOrderClosed
+	}
+
+	type orderLine struct {
+		id, quantity, received int
+		unitCost               models.Money
+	}
+	rows, err := tx.Query(`
+		SELECT id, product_id, quantity, received_quantity, unit_cost
+		FROM purchase_order_lines WHERE purchase_order_id = $1
+	`, id)
+	if err != nil {
+		return 0, err
+	}
+	lines := make(map[int]*orderLine)
+	for rows.Next() {
+		var productID int
+		var l orderLine
+		if err := rows.Scan(&l.id, &productID, &l.quantity, &l.received, &l.unitCost); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		lines[productID] = &l
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var receiptID int
+	err = tx.QueryRow(`
+		INSERT INTO goods_receipts (purchase_order_id, note, received_by) VALUES ($1, $2, $3) RETURNING id
+	`, id, req.Note, req.ReceivedBy).Scan(&receiptID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Kunci produk berurutan ID agar tidak deadlock dengan checkout
+	items := append([]models.GoodsReceiptLineRequest(nil), req.Lines...)
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+
+	for _, item := range items {
+		l, ok := lines[item.ProductID]
+		if !ok {
+			return 0, fmt.Errorf("%w: product %d", ErrProductNotInOrder, item.ProductID)
+		}
+		if l.received+item.Quantity > l.quantity {
+			return 0, fmt.Errorf("%w: product %d has %d remaining", ErrReceiptExceedsOrder, item.ProductID, l.quantity-l.received)
+		}
+		unitCost := l.unitCost
+		if item.UnitCost != nil {
+			unitCost = *item.UnitCost
+		}
+
+		var stock int
+		var costPrice models.Money
+		err := tx.QueryRow("SELECT stock, cost_price FROM products WHERE id = $1 FOR UPDATE", item.ProductID).Scan(&stock, &costPrice)
+		if err != nil {
+			return 0, err
+		}
+		// Resep atau varian bisa ditambahkan setelah PO dibuat
+		if err := checkOwnStock(tx, item.ProductID); err != nil {
+			return 0, err
+		}
+		newCost := unitCost
+		if stock > 0 {
+			value := costPrice.Mul(int64(stock)).Add(unitCost.Mul(int64(item.Quantity)))
+			newCost = value.MulRatio(1, int64(stock+item.Quantity))
+		}
+		if _, err := tx.Exec("UPDATE products SET cost_price = $1, updated_at = NOW() WHERE id = $2", newCost, item.ProductID); err != nil {
+			return 0, err
+		}
+
+		movement := models.StockMovement{
+			ProductID:   item.ProductID,
+			Type:        models.StockMovementPurchaseReceipt,
+			Quantity:    item.Quantity,
+			ReferenceID: &receiptID,
+			Note:        fmt.Sprintf("PO #%d", id),
+			User:        req.ReceivedBy,
+		}
+		if item.BatchNumber != "" || item.ExpiresAt != nil {
+			batchID, err := createBatch(tx, item.ProductID, item.BatchNumber, item.ExpiresAt, item.Quantity, &receiptID)
+			if err != nil {
+				return 0, err
+			}
+			movement.BatchID = &batchID
+		}
+		if err := changeStock(tx, &movement); err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO goods_receipt_lines (goods_receipt_id, purchase_order_line_id, product_id, quantity, unit_cost, batch_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, receiptID, l.id, item.ProductID, item.Quantity, unitCost, movement.BatchID)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec("UPDATE purchase_order_lines SET received_quantity = received_quantity + $1 WHERE id = $2", item.Quantity, l.id); err != nil {
+			return 0, err
+		}
+		l.received += item.Quantity
+	}
+
+	status = models.PurchaseOrderStatusReceived
+	for _, l := range lines {
+		if l.received < l.quantity {
+			status = models.PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	if _, err := tx.Exec("UPDATE purchase_orders SET status = $1, updated_at = NOW() WHERE id = $2", status, id); err != nil {
+		return 0, err
+	}
+	return receiptID, tx.Commit()
+}
+
+// GetPurchaseReport merekap barang yang diterima per supplier pada rentang [query.Start, query.End).
+func (r *purchaseOrderRepository) GetPurchaseReport(query models.ReportQuery, supplierID int) (models.PurchaseReport, error) {
+	report := models.PurchaseReport{
+		StartDate: query.Start,
+		EndDate:   query.End,
+		Suppliers: make([]models.SupplierPurchaseSummary, 0),
+		TotalCost: models.Rupiah(0),
+	}
+
+	rows, err := r.db.Query(`
+		SELECT s.id, s.name, COUNT(DISTINCT po.id), COUNT(DISTINCT gr.id), SUM(grl.quantity), SUM(grl.quantity * grl.unit_cost)
+		FROM goods_receipts gr
+		JOIN goods_receipt_lines grl ON grl.goods_receipt_id = gr.id
+		JOIN purchase_orders po ON gr.purchase_order_id = po.id
+		JOIN suppliers s ON po.supplier_id = s.id
+		WHERE gr.received_at >= $1 AND gr.received_at < $2 AND ($3 = 0 OR s.id = $3)
+		GROUP BY s.id, s.name
+		ORDER BY SUM(grl.quantity * grl.unit_cost) DESC, s.id
+	`, query.Start, query.End, supplierID)
+	if err != nil {
+		return report, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s models.SupplierPurchaseSummary
+		if err := rows.Scan(&s.SupplierID, &s.SupplierName, &s.PurchaseOrders, &s.Receipts, &s.Quantity, &s.TotalCost); err != nil {
+			return report, err
+		}
+		report.Suppliers = append(report.Suppliers, s)
+		report.Quantity += s.Quantity
+		report.TotalCost = report.TotalCost.Add(s.TotalCost)
+	}
+	return report, rows.Err()
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"database/sql"
+	"kasir-api/models"
+)
+
+// GetSalesReport menghitung laporan untuk rentang [query.Start, query.End).
+func (repo *transactionRepository) GetSalesReport(query models.ReportQuery) (models.SalesReport, error) {
+	start, end := query.Start, query.End
+	report := models.SalesReport{StartDate: start, EndDate: end}
+
+	// 1. Total Revenue & Total Transaksi. Transaksi void tetap masuk gross karena pembatalannya tercatat
+	// sebagai refund, tapi tidak dihitung sebagai transaksi.
+	// Nominal dijumlahkan langsung di SQL, jadi transaksi dengan mata uang lain harus ditolak lebih dulu
+	queryStats := `
+		SELECT COALESCE(SUM(total_amount), 0), COUNT(id) FILTER (WHERE status <> 'voided'),
+		       COALESCE(MIN(currency) FILTER (WHERE currency <> $3), '')
+		FROM transactions
+		WHERE created_at >= $1 AND created_at < $2
+	`
+	var otherCurrency string
+	err := repo.db.QueryRow(queryStats, start, end, models.DefaultCurrency).Scan(&report.GrossRevenue, &report.TotalTransaksi, &otherCurrency)
+	if err != nil {
+		return report, err
+	}
+	if _, err := models.ParseCurrency(otherCurrency); err != nil {
+		return report, err
+	}
+
+	// Refund dihitung pada tanggal refund dibuat, bukan tanggal penjualan
+	queryRefunds := `
+		SELECT COALESCE(SUM(total_amount), 0)
+		FROM transaction_refunds
+		WHERE created_at >= $1 AND created_at < $2
+	`
+	err = repo.db.QueryRow(queryRefunds, start, end).Scan(&report.TotalRefund)
+	if err != nil {
+		return report, err
+	}
+	report.NetRevenue = report.GrossRevenue.Add(report.TotalRefund)
+	report.TotalRevenue = report.NetRevenue
+
+	// 2. Produk Terlaris (dikelompokkan per ID agar produk dengan nama sama tidak tergabung;
+	// nama diambil dari snapshot transaksi terakhir), quantity setelah dikurangi refund
+	queryBestSeller := `
+		SELECT (ARRAY_AGG(td.product_name ORDER BY td.id DESC))[1], SUM(` + netQuantity + `) as qty
+		FROM ` + soldDetails + `
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY td.product_id
+		HAVING SUM(` + netQuantity + `) > 0
+		ORDER BY qty DESC, td.product_id
+		LIMIT 1
+	`
+	err = repo.db.QueryRow(queryBestSeller, start, end).Scan(&report.ProdukTerlaris.Name, &report.ProdukTerlaris.QtyTerjual)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			report.ProdukTerlaris = models.BestSellingProduct{Name: "-", QtyTerjual: 0}
+		} else {
+			return report, err
+		}
+	}
+
+	// 3. Rekap per metode pembayaran, tanpa transaksi yang di-void
+	if report.Pembayaran, err = repo.paymentSummary(query); err != nil {
+		return report, err
+	}
+
+	// 4. Bagian opsional
+	if query.Sections[models.ReportSectionTopProducts] {
+		if report.TopByQuantity, err = repo.topProducts(query, "qty"); err != nil {
+			return report, err
+		}
+		if report.TopByRevenue, err = repo.topProducts(query, "revenue"); err != nil {
+			return report, err
+		}
+	}
+	if query.Sections[models.ReportSectionCategories] {
+		if report.Categories, err = repo.categorySales(query); err != nil {
+			return report, err
+		}
+	}
+	if query.Sections[models.ReportSectionBasket] {
+		if report.Basket, err = repo.basketStats(query, report); err != nil {
+			return report, err
+		}
+	}
+	if query.Sections[models.ReportSectionHourly] {
+		if report.Hourly, err = repo.hourlySales(query); err != nil {
+			return report, err
+		}
+	}
+	if query.Sections[models.ReportSectionProfit] {
+		if report.Profit, err = repo.profitSummary(query); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func (repo *transactionRepository) paymentSummary(query models.ReportQuery) ([]models.PaymentMethodSummary, error) {
+	rows, err := repo.db.Query(`
+		SELECT tp.method, COUNT(DISTINCT tp.transaction_id), COALESCE(SUM(tp.amount), 0)
+		FROM transaction_payments tp
+		JOIN transactions t ON tp.transaction_id = t.id AND t.status <> 'voided'
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY tp.method
+		ORDER BY tp.method
+	`, query.Start, query.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := make([]models.PaymentMethodSummary, 0)
+	for rows.Next() {
+		var p models.PaymentMethodSummary
+		if err := rows.Scan(&p.Method, &p.TotalTransaksi, &p.TotalPembayaran); err != nil {
+			return nil, err
+		}
+		summary = append(summary, p)
+	}
+	return summary, rows.Err()
+}
+
+// Revenue per produk / kategori / hari memakai harga sebelum pajak dan service charge
+const detailRevenue = "(td.taxable_amount - td.service_charge)"
+
+// soldDetails adalah baris penjualan dari transaksi yang tidak di-void beserta quantity yang sudah direfund (r.quantity).
+// Refund dikurangkan dari baris penjualannya, jadi angka per produk / kategori mengikuti tanggal penjualan.
+const soldDetails = `transaction_details td
+	JOIN transactions t ON td.transaction_id = t.id AND t.status <> 'voided'
+	LEFT JOIN (
+		SELECT transaction_detail_id, SUM(quantity) AS quantity
+		FROM transaction_refund_items
+		GROUP BY transaction_detail_id
+	) r ON r.transaction_detail_id = td.id`
+
+// netQuantity adalah quantity baris setelah dikurangi refund.
+const netQuantity = "(td.quantity - COALESCE(r.quantity, 0))"
+
+// Revenue dan harga pokok baris setelah refund. Refund dihitung proporsional terhadap quantity, sama seperti
+// nominal refund-nya.
+const (
+	netDetailRevenue = "ROUND(" + detailRevenue + "::numeric * " + netQuantity + " / td.quantity)::bigint"
+	netDetailCost    = "(td.unit_cost * " + netQuantity + ")"
+)
+
+// topProducts mengurutkan produk berdasarkan orderBy ("qty" atau "revenue"), maksimal query.TopN baris.
+func (repo *transactionRepository) topProducts(query models.ReportQuery, orderBy string) ([]models.ProductSales, error) {
+	rows, err := repo.db.Query(`
+		SELECT td.product_id, (ARRAY_AGG(td.product_name ORDER BY td.id DESC))[1],
+		       SUM(`+netQuantity+`) AS qty, SUM(`+netDetailRevenue+`) AS revenue, SUM(`+netDetailCost+`) AS cost
+		FROM `+soldDetails+`
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY td.product_id
+		HAVING SUM(`+netQuantity+`) > 0
+		ORDER BY `+orderBy+` DESC, td.product_id
+		LIMIT $3
+	`, query.Start, query.End, query.TopN)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.ProductSales, 0)
+	for rows.Next() {
+		var p models.ProductSales
+		if err := rows.Scan(&p.ProductID, &p.Name, &p.QtyTerjual, &p.Revenue, &p.Cost); err != nil {
+			return nil, err
+		}
+		p.GrossProfit, p.MarginPercent = profitAndMargin(p.Revenue, p.Cost)
+		products = append(products, p)
+	}
+	return products, rows.Err()
+}
+
+func (repo *transactionRepository) categorySales(query models.ReportQuery) ([]models.CategorySales, error) {
+	rows, err := repo.db.Query(`
+		SELECT c.id, c.name, SUM(`+netQuantity+`) AS qty, SUM(`+netDetailRevenue+`) AS revenue, SUM(`+netDetailCost+`) AS cost
+		FROM `+soldDetails+`
+		JOIN categories c ON td.category_id = c.id
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY c.id, c.name
+		HAVING SUM(`+netQuantity+`) > 0
+		ORDER BY revenue DESC, c.id
+	`, query.Start, query.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.CategorySales, 0)
+	for rows.Next() {
+		var c models.CategorySales
+		if err := rows.Scan(&c.CategoryID, &c.Name, &c.QtyTerjual, &c.Revenue, &c.Cost); err != nil {
+			return nil, err
+		}
+		c.GrossProfit, c.MarginPercent = profitAndMargin(c.Revenue, c.Cost)
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+func (repo *transactionRepository) basketStats(query models.ReportQuery, report models.SalesReport) (*models.BasketStats, error) {
+	stats := &models.BasketStats{}
+	if report.TotalTransaksi == 0 {
+		return stats, nil
+	}
+
+	// Transaksi yang sama dengan TotalTransaksi, nilai keranjang setelah refund per baris
+	var totalItems int
+	var totalValue models.Money
+	err := repo.db.QueryRow(`
+		SELECT COALESCE(SUM(`+netQuantity+`), 0), COALESCE(SUM(`+netDetailRevenue+`), 0)
+		FROM `+soldDetails+`
+		WHERE t.created_at >= $1 AND t.created_at < $2
+	`, query.Start, query.End).Scan(&totalItems, &totalValue)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.AvgBasketValue = totalValue.MulRatio(1, int64(report.TotalTransaksi))
+	stats.AvgItemsPerTransaction = float64(totalItems) / float64(report.TotalTransaksi)
+	return stats, nil
+}
+
+// hourlySales selalu mengembalikan 24 jam (0-23) menurut zona waktu laporan, termasuk jam tanpa penjualan.
+// Jumlah transaksi semua jam sama dengan TotalTransaksi dan revenue-nya sama dengan revenue laba kotor.
+func (repo *transactionRepository) hourlySales(query models.ReportQuery) ([]models.HourlySales, error) {
+	hourly := make([]models.HourlySales, 24)
+	for h := range hourly {
+		hourly[h].Hour = h
+	}
+
+	rows, err := repo.db.Query(`
+		SELECT EXTRACT(HOUR FROM t.created_at AT TIME ZONE $3)::int AS hour, COUNT(DISTINCT t.id), SUM(`+netDetailRevenue+`)
+		FROM `+soldDetails+`
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY hour
+	`, query.Start, query.End, query.Location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour, count int
+		var revenue models.Money
+		if err := rows.Scan(&hour, &count, &revenue); err != nil {
+			return nil, err
+		}
+		hourly[hour].TotalTransaksi = count
+		hourly[hour].Revenue = revenue
+	}
+	return hourly, rows.Err()
+}
+
+// profitSummary menghitung laba kotor periode laporan beserta rinciannya per hari, setelah dikurangi refund
+// dan tanpa transaksi yang di-void.
+func (repo *transactionRepository) profitSummary(query models.ReportQuery) (*models.ProfitSummary, error) {
+	rows, err := repo.db.Query(`
+		SELECT TO_CHAR((t.created_at AT TIME ZONE $3)::date, 'YYYY-MM-DD') AS tanggal,
+		       SUM(`+netDetailRevenue+`), SUM(`+netDetailCost+`)
+		FROM `+soldDetails+`
+		WHERE t.created_at >= $1 AND t.created_at < $2
+		GROUP BY tanggal
+		ORDER BY tanggal
+	`, query.Start, query.End, query.Location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := &models.ProfitSummary{
+		Revenue: models.Rupiah(0),
+		Cost:    models.Rupiah(0),
+		PerHari: make([]models.DailyProfit, 0),
+	}
+	for rows.Next() {
+		var d models.DailyProfit
+		if err := rows.Scan(&d.Tanggal, &d.Revenue, &d.Cost); err != nil {
+			return nil, err
+		}
+		d.GrossProfit, d.MarginPercent = profitAndMargin(d.Revenue, d.Cost)
+		summary.PerHari = append(summary.PerHari, d)
+
+		summary.Revenue = summary.Revenue.Add(d.Revenue)
+		summary.Cost = summary.Cost.Add(d.Cost)
+	}
+	summary.GrossProfit, summary.MarginPercent = profitAndMargin(summary.Revenue, summary.Cost)
+	return summary, rows.Err()
+}
+
+// profitAndMargin mengembalikan laba kotor dan margin dalam persen terhadap revenue.
+func profitAndMargin(revenue, cost models.Money) (models.Money, float64) {
+	profit := revenue.Sub(cost)
+	if revenue.IsZero() {
+		return profit, 0
+	}
+	return profit, float64(profit.Amount) / float64(revenue.Amount) * 100
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"kasir-api/models"
+	"testing"
+	"time"
+)
+
+// Transaksi yang di-void tidak ikut dihitung di bagian mana pun, dan histogram per jam berjumlah sama dengan
+// total transaksi dan revenue laba kotor.
+func TestSalesReportExcludesVoided(t *testing.T) {
+	db := testDB(t)
+	product := createTestProduct(t, db, 10)
+	repo := NewTransactionRepository(db)
+	query := models.ReportQuery{
+		Start:    time.Now().Add(-time.Hour),
+		End:      time.Now().Add(time.Hour),
+		Location: time.UTC,
+		Sections: map[string]bool{models.ReportSectionHourly: true, models.ReportSectionProfit: true, models.ReportSectionBasket: true},
+	}
+	report := func() models.SalesReport {
+		t.Helper()
+		r, err := repo.GetSalesReport(query)
+		if err != nil {
+			t.Fatalf("sales report: %v", err)
+		}
+		return r
+	}
+	payments := func(r models.SalesReport) (count int) {
+		for _, p := range r.Pembayaran {
+			count += p.TotalTransaksi
+		}
+		return count
+	}
+
+	before := report()
+	transaction, err := repo.CreateTransaction(models.CheckoutRequest{
+		Items: []models.CheckoutItem{{ProductID: product.ID, Quantity: 2}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if _, err := repo.VoidTransaction(transaction.ID, models.VoidRequest{Reason: "test", User: "tester"}); err != nil {
+		t.Fatalf("void: %v", err)
+	}
+	after := report()
+
+	if after.TotalTransaksi != before.TotalTransaksi {
+		t.Errorf("TotalTransaksi = %d, want %d", after.TotalTransaksi, before.TotalTransaksi)
+	}
+	if payments(after) != payments(before) {
+		t.Errorf("payment count = %d, want %d", payments(after), payments(before))
+	}
+	if after.Profit.Revenue != before.Profit.Revenue {
+		t.Errorf("profit revenue = %s, want %s", after.Profit.Revenue, before.Profit.Revenue)
+	}
+
+	count, revenue := 0, models.Rupiah(0)
+	for _, h := range after.Hourly {
+		count += h.TotalTransaksi
+		revenue = revenue.Add(h.Revenue)
+	}
+	if count != after.TotalTransaksi || revenue != after.Profit.Revenue {
+		t.Errorf("hourly totals = %d / %s, want %d / %s", count, revenue, after.TotalTransaksi, after.Profit.Revenue)
+	}
+	if after.TotalTransaksi > 0 {
+		if want := after.Profit.Revenue.MulRatio(1, int64(after.TotalTransaksi)); after.Basket.AvgBasketValue != want {
+			t.Errorf("AvgBasketValue = %s, want %s", after.Basket.AvgBasketValue, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"kasir-api/models"
+	"time"
+)
+
+type StockAlertRepository interface {
+	CollectAlerts() (int, error)
+	FetchPending(limit int) ([]models.LowStockAlert, error)
+	MarkNotified(id int) error
+	RecordFailure(id int, message string, retryAt *time.Time) error
+}
+
+type stockAlertRepository struct {
+	db *sql.DB
+}
+
+func NewStockAlertRepository(db *sql.DB) *stockAlertRepository {
+	return &stockAlertRepository{db: db}
+}
+
+// CollectAlerts membuat alert untuk setiap penjualan yang membuat stok turun melewati reorder point.
+// Penjualan satu hari terakhir diperiksa ulang setiap kali, tetapi satu movement hanya menghasilkan satu alert,
+// sehingga transaksi yang commit terlambat tetap ikut terperiksa.
+func (r *stockAlertRepository) CollectAlerts() (int, error) {
+	res, err := r.db.Exec(`
+		INSERT INTO low_stock_alerts (product_id, stock_movement_id, stock, reorder_point, reorder_quantity)
+		SELECT m.product_id, m.id, m.stock_after, p.reorder_point, p.reorder_quantity
+		FROM stock_movements m
+		JOIN products p ON p.id = m.product_id
+		WHERE m.type = $1
+		  AND m.created_at > NOW() - INTERVAL '1 day'
+		  AND p.deleted_at IS NULL
+		  AND p.reorder_point > 0
+		  AND m.stock_after <= p.reorder_point
+		  AND m.stock_after - m.quantity > p.reorder_point
+		ON CONFLICT (stock_movement_id) DO NOTHING
+	`, models.StockMovementSale)
+	if err != nil {
+		return 0, err
+	}
+	created, _ := res.RowsAffected()
+	return int(created), nil
+}
+
+// FetchPending mengambil alert yang belum terkirim dan sudah waktunya dicoba (lagi).
+func (r *stockAlertRepository) FetchPending(limit int) ([]models.LowStockAlert, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.product_id, p.name, COALESCE(p.sku, ''), a.stock_movement_id, a.stock, a.reorder_point,
+		       a.reorder_quantity, a.created_at, a.attempts, a.last_error
+		FROM low_stock_alerts a
+		JOIN products p ON p.id = a.product_id
+		WHERE a.notified_at IS NULL AND a.failed_at IS NULL AND a.next_attempt_at <= NOW()
+		ORDER BY a.next_attempt_at, a.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := make([]models.LowStockAlert, 0)
+	for rows.Next() {
+		var a models.LowStockAlert
+		err := rows.Scan(&a.ID, &a.ProductID, &a.ProductName, &a.SKU, &a.StockMovementID, &a.Stock, &a.ReorderPoint,
+			&a.ReorderQuantity, &a.CreatedAt, &a.Attempts, &a.LastError)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+func (r *stockAlertRepository) MarkNotified(id int) error {
+	_, err := r.db.Exec("UPDATE low_stock_alerts SET notified_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+// RecordFailure mencatat pengiriman yang gagal. Alert dicoba lagi pada retryAt, atau tidak dicoba lagi
+// (failed_at diisi) jika retryAt nil.
+func (r *stockAlertRepository) RecordFailure(id int, message string, retryAt *time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE low_stock_alerts
+		SET attempts = attempts + 1, last_error = $2,
+		    next_attempt_at = COALESCE($3, next_attempt_at),
+		    failed_at = CASE WHEN $3::timestamptz IS NULL THEN NOW() END
+		WHERE id = $1
+	`, id, message, retryAt)
+	return err
+}
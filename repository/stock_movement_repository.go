@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+var (
+	ErrBatchNotFound   = errors.New("batch not found")
+	ErrBatchNotExpired = errors.New("batch has not expired")
+)
+
+type StockMovementRepository interface {
+	FetchByProduct(productID, limit, beforeID int) ([]models.StockMovement, error)
+	Reconcile() (models.StockReconciliation, error)
+	Adjust(movement *models.StockMovement, newBatch *models.ProductBatch) error
+}
+
+type stockMovementRepository struct {
+	db *sql.DB
+}
+
+func NewStockMovementRepository(db *sql.DB) *stockMovementRepository {
+	return &stockMovementRepository{db: db}
+}
+
+// changeStock menambah stok produk sebesar m.Quantity (negatif berarti mengurangi) dan mencatatnya di ledger
+// dalam transaksi yang sama. Stok tidak boleh menjadi minus; jika kurang, ErrInsufficientStock dikembalikan.
+// Setiap perubahan stok harus lewat fungsi ini agar ledger selalu seimbang dengan products.stock.
+//
+// Batch ikut diperbarui: jika m.BatchID diisi, perubahan dikenakan ke batch itu. Jika tidak, pengurangan
+// diambil dari batch yang paling cepat kadaluarsa (FEFO) lalu dari stok tanpa batch, sedangkan penambahan
+// (mis. refund) masuk ke stok tanpa batch.
+func changeStock(tx *sql.Tx, m *models.StockMovement) error {
+	err := tx.QueryRow("UPDATE products SET stock = stock + $1 WHERE id = $2 AND stock + $1 >= 0 RETURNING stock", m.Quantity, m.ProductID).
+		Scan(&m.StockAfter)
+	if err == sql.ErrNoRows {
+		return ErrInsufficientStock
+	}
+	if err != nil {
+		return err
+	}
+
+	// Baris produk sudah terkunci oleh UPDATE di atas, jadi batch produk ini tidak bisa berubah bersamaan
+	if m.BatchID != nil {
+		err = changeBatch(tx, m)
+	} else if m.Quantity < 0 {
+		err = consumeBatches(tx, m)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := recordStockMovement(tx, m); err != nil {
+		return err
+	}
+	for _, b := range m.Batches {
+		_, err := tx.Exec(`
+			INSERT INTO stock_movement_batches (stock_movement_id, batch_id, quantity) VALUES ($1, $2, $3)
+		`, m.ID, b.BatchID, b.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func changeBatch(tx *sql.Tx, m *models.StockMovement) error {
+	b := models.StockMovementBatch{BatchID: *m.BatchID, Quantity: m.Quantity}
+	var quantity int
+	var expiresAt sql.NullTime
+	err := tx.QueryRow(`
+		SELECT quantity, batch_number, expires_at FROM product_batches WHERE id = $1 AND product_id = $2
+	`, b.BatchID, m.ProductID).Scan(&quantity, &b.BatchNumber, &expiresAt)
+	if err == sql.ErrNoRows {
+		return ErrBatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if quantity+m.Quantity < 0 {
+		return fmt.Errorf("%w in batch %s", ErrInsufficientStock, b.BatchNumber)
+	}
+	b.ExpiresAt = dateString(expiresAt)
+
+	if _, err := tx.Exec("UPDATE product_batches SET quantity = quantity + $1 WHERE id = $2", m.Quantity, b.BatchID); err != nil {
+		return err
+	}
+	m.Batches = []models.StockMovementBatch{b}
+	return nil
+}
+
+// consumeBatches mengambil -m.Quantity dari batch secara FEFO. Untuk penjualan, batch yang sudah kadaluarsa
+// dilewati; jika sisa stok hanya ada di batch kadaluarsa, penjualan ditolak.
+func consumeBatches(tx *sql.Tx, m *models.StockMovement) error {
+	rows, err := tx.Query(`
+		SELECT id, batch_number, expires_at, quantity
+		FROM product_batches
+		WHERE product_id = $1 AND quantity > 0 AND ($2 = '' OR expires_at IS NULL OR expires_at >= $2::date)
+		ORDER BY expires_at NULLS LAST, id
+	`, m.ProductID, m.SellableOn)
+	if err != nil {
+		return err
+	}
+
+	need := -m.Quantity
+	for rows.Next() && need > 0 {
+		var available int
+		var expiresAt sql.NullTime
+		b := models.StockMovementBatch{}
+		if err := rows.Scan(&b.BatchID, &b.BatchNumber, &expiresAt, &available); err != nil {
+			rows.Close()
+			return err
+		}
+		b.ExpiresAt = dateString(expiresAt)
+		b.Quantity = -min(available, need)
+		need += b.Quantity
+		m.Batches = append(m.Batches, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, b := range m.Batches {
+		if _, err := tx.Exec("UPDATE product_batches SET quantity = quantity + $1 WHERE id = $2", b.Quantity, b.BatchID); err != nil {
+			return err
+		}
+	}
+
+	// Sisa pengurangan diambil dari stok tanpa batch, yang tidak boleh habis melewati jumlah batch
+	var batched int
+	err = tx.QueryRow("SELECT COALESCE(SUM(quantity), 0) FROM product_batches WHERE product_id = $1", m.ProductID).Scan(&batched)
+	if err != nil {
+		return err
+	}
+	if batched > m.StockAfter {
+		return fmt.Errorf("%w: remaining stock is in expired batches", ErrInsufficientStock)
+	}
+	return nil
+}
+
+// createBatch membuat batch kosong; stoknya diisi lewat changeStock dengan BatchID batch ini.
+func createBatch(tx *sql.Tx, productID int, batchNumber string, expiresAt *string, quantity int, goodsReceiptID *int) (int, error) {
+	var id int
+	err := tx.QueryRow(`
+		INSERT INTO product_batches (product_id, batch_number, expires_at, initial_quantity, goods_receipt_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, productID, batchNumber, expiresAt, quantity, goodsReceiptID).Scan(&id)
+	return id, err
+}
+
+func dateString(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	date := t.Time.Format("2006-01-02")
+	return &date
+}
+
+// recordStockMovement hanya menulis ledger, untuk stok yang sudah diubah oleh query lain (mis. INSERT produk baru).
+func recordStockMovement(tx *sql.Tx, m *models.StockMovement) error {
+	return tx.QueryRow(`
+		INSERT INTO stock_movements (product_id, type, quantity, stock_after, reference_id, reason, note, user_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at
+	`, m.ProductID, m.Type, m.Quantity, m.StockAfter, m.ReferenceID, m.Reason, m.Note, m.User).Scan(&m.ID, &m.CreatedAt)
+}
+
+// Adjust menerapkan penyesuaian stok relatif. Delta langsung ditambahkan ke stok di database,
+// sehingga tidak menimpa pengurangan dari penjualan yang berjalan bersamaan. newBatch diisi untuk
+// penambahan stok sebagai batch baru. Produk komposit dan induk varian tidak bisa disesuaikan karena stoknya selalu 0.
+func (r *stockMovementRepository) Adjust(m *models.StockMovement, newBatch *models.ProductBatch) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkOwnStock(tx, m.ProductID); err != nil {
+		return err
+	}
+
+	if newBatch != nil {
+		batchID, err := createBatch(tx, m.ProductID, newBatch.BatchNumber, newBatch.ExpiresAt, m.Quantity, nil)
+		if err != nil {
+			return err
+		}
+		m.BatchID = &batchID
+	}
+	if err := changeStock(tx, m); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FetchByProduct mengembalikan movement terbaru lebih dulu. beforeID > 0 mengambil halaman setelah movement tersebut.
+func (r *stockMovementRepository) FetchByProduct(productID, limit, beforeID int) ([]models.StockMovement, error) {
+	query := `
+		SELECT id, product_id, type, quantity, stock_after, reference_id, reason, note, user_name, created_at
+		FROM stock_movements
+		WHERE product_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(query, productID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := make([]models.StockMovement, 0)
+	for rows.Next() {
+		var m models.StockMovement
+		var referenceID sql.NullInt64
+		err := rows.Scan(&m.ID, &m.ProductID, &m.Type, &m.Quantity, &m.StockAfter, &referenceID, &m.Reason, &m.Note, &m.User, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if referenceID.Valid {
+			id := int(referenceID.Int64)
+			m.ReferenceID = &id
+		}
+		movements = append(movements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return movements, loadMovementBatches(r.db, movements)
+}
+
+func loadMovementBatches(q querier, movements []models.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(movements))
+	index := make(map[int64]int, len(movements))
+	for i, m := range movements {
+		ids[i] = int64(m.ID)
+		index[int64(m.ID)] = i
+	}
+
+	rows, err := q.Query(`
+		SELECT smb.stock_movement_id, smb.batch_id, b.batch_number, b.expires_at, smb.quantity
+		FROM stock_movement_batches smb
+		JOIN product_batches b ON b.id = smb.batch_id
+		WHERE smb.stock_movement_id = ANY($1)
+		ORDER BY smb.stock_movement_id, b.expires_at NULLS LAST, b.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movementID int64
+		var expiresAt sql.NullTime
+		var b models.StockMovementBatch
+		if err := rows.Scan(&movementID, &b.BatchID, &b.BatchNumber, &expiresAt, &b.Quantity); err != nil {
+			return err
+		}
+		b.ExpiresAt = dateString(expiresAt)
+		m := &movements[index[movementID]]
+		m.Batches = append(m.Batches, b)
+	}
+	return rows.Err()
+}
+
+// Reconcile membandingkan jumlah ledger per produk dengan products.stock.
+func (r *stockMovementRepository) Reconcile() (models.StockReconciliation, error) {
+	result := models.StockReconciliation{CheckedAt: time.Now(), Mismatches: make([]models.StockMismatch, 0)}
+
+	rows, err := r.db.Query(`
+		SELECT p.id, p.name, p.stock, COALESCE(SUM(m.quantity), 0) AS ledger
+		FROM products p
+		LEFT JOIN stock_movements m ON m.product_id = p.id
+		WHERE p.deleted_at IS NULL
+		GROUP BY p.id, p.name, p.stock
+		ORDER BY p.id
+	`)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.StockMismatch
+		if err := rows.Scan(&m.ProductID, &m.Name, &m.Stock, &m.LedgerStock); err != nil {
+			return result, err
+		}
+		result.ProductsChecked++
+		if m.Stock != m.LedgerStock {
+			m.Difference = m.Stock - m.LedgerStock
+			result.Mismatches = append(result.Mismatches, m)
+		}
+	}
+	result.Balanced = len(result.Mismatches) == 0
+	return result, rows.Err()
+}
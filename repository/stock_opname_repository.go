@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+)
+
+var (
+	ErrStockOpnameNotFound   = errors.New("stock opname not found")
+	ErrStockOpnameClosed     = errors.New("stock opname is already approved or cancelled")
+	ErrStockOpnameInProgress = errors.New("another stock opname is in progress for these products")
+	ErrStockOpnameEmpty      = errors.New("no products to count")
+	ErrProductNotInOpname    = errors.New("product is not in stock opname")
+	ErrProductUnderCount     = errors.New("product is being counted in a stock opname")
+)
+
+type StockOpnameRepository interface {
+	FetchAll(status string) ([]models.StockOpname, error)
+	FetchByID(id int) (*models.StockOpname, error)
+	Create(req models.CreateStockOpnameRequest) (int, error)
+	SubmitCounts(id int, req models.StockOpnameCountRequest) error
+	Approve(id int, user string) error
+	Cancel(id int, user string) error
+}
+
+type stockOpnameRepository struct {
+	db *sql.DB
+}
+
+func NewStockOpnameRepository(db *sql.DB) *stockOpnameRepository {
+	return &stockOpnameRepository{db: db}
+}
+
+// underCount bernilai true jika produk p sedang dihitung dalam sesi opname yang memblokir penjualan.
+const underCount = `EXISTS (
+	SELECT 1 FROM stock_opname_items oi
+	JOIN stock_opnames o ON o.id = oi.opname_id
+	WHERE oi.product_id = p.id AND o.status = 'counting' AND o.block_sales
+)`
+
+const stockOpnameColumns = `id, status, category_id, block_sales, note, created_by, closed_by, created_at, closed_at`
+
+func scanStockOpname(row rowScanner) (models.StockOpname, error) {
+	var o models.StockOpname
+	var categoryID sql.NullInt64
+	var closedAt sql.NullTime
+	err := row.Scan(&o.ID, &o.Status, &categoryID, &o.BlockSales, &o.Note, &o.CreatedBy, &o.ClosedBy, &o.CreatedAt, &closedAt)
+	if categoryID.Valid {
+		id := int(categoryID.Int64)
+		o.CategoryID = &id
+	}
+	if closedAt.Valid {
+		o.ClosedAt = &closedAt.Time
+	}
+	return o, err
+}
+
+// FetchAll mengambil sesi opname beserta ringkasannya, tanpa rincian per produk.
+func (r *stockOpnameRepository) FetchAll(status string) ([]models.StockOpname, error) {
+	rows, err := r.db.Query(`
+		SELECT `+stockOpnameColumns+` FROM stock_opnames
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY id DESC
+	`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	opnames := make([]models.StockOpname, 0)
+	for rows.Next() {
+		o, err := scanStockOpname(rows)
+		if err != nil {
+			return nil, err
+		}
+		opnames = append(opnames, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadStockOpnameItems(r.db, opnames); err != nil {
+		return nil, err
+	}
+	for i := range opnames {
+		opnames[i].Items = nil
+	}
+	return opnames, nil
+}
+
+func (r *stockOpnameRepository) FetchByID(id int) (*models.StockOpname, error) {
+	o, err := scanStockOpname(r.db.QueryRow(`SELECT `+stockOpnameColumns+` FROM stock_opnames WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrStockOpnameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opnames := []models.StockOpname{o}
+	if err := loadStockOpnameItems(r.db, opnames); err != nil {
+		return nil, err
+	}
+	return &opnames[0], nil
+}
+
+// loadStockOpnameItems mengisi Items (snapshot, hasil hitung, selisih) dan Summary untuk beberapa sesi sekaligus.
+func loadStockOpnameItems(q querier, opnames []models.StockOpname) error {
+	if len(opnames) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(opnames))
+	index := make(map[int]int, len(opnames))
+	for i := range opnames {
+		ids[i] = opnames[i].ID
+		index[opnames[i].ID] = i
+		opnames[i].Items = make([]models.StockOpnameItem, 0)
+	}
+
+	type itemPos struct{ opname, item int }
+	items := make(map[[2]int]itemPos)
+
+	rows, err := q.Query(`
+		SELECT oi.opname_id, oi.product_id, p.name, COALESCE(p.sku, ''), oi.expected_stock, oi.cost_price, p.stock
+		FROM stock_opname_items oi
+		JOIN products p ON p.id = oi.product_id
+		WHERE oi.opname_id = ANY($1)
+		ORDER BY oi.opname_id, oi.product_id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var opnameID, currentStock int
+		var item models.StockOpnameItem
+		err := rows.Scan(&opnameID, &item.ProductID, &item.ProductName, &item.SKU, &item.ExpectedStock, &item.CostPrice, &currentStock)
+		if err != nil {
+			return err
+		}
+		o := &opnames[index[opnameID]]
+		// Setelah sesi ditutup stok sudah termasuk koreksi, jadi pergerakan hanya relevan selama hitung
+		if o.Status == models.StockOpnameStatusCounting {
+			item.MovedDuringCount = currentStock - item.ExpectedStock
+			item.Flagged = item.MovedDuringCount != 0
+		}
+		item.Counts = make([]models.StockOpnameCount, 0)
+		o.Items = append(o.Items, item)
+		items[[2]int{opnameID, item.ProductID}] = itemPos{index[opnameID], len(o.Items) - 1}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Urut waktu hitung agar stok sistem yang tersisa di item adalah milik hitungan terakhir
+	countRows, err := q.Query(`
+		SELECT opname_id, product_id, counted_by, quantity, system_stock, counted_at
+		FROM stock_opname_counts
+		WHERE opname_id = ANY($1)
+		ORDER BY counted_at, id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer countRows.Close()
+
+	for countRows.Next() {
+		var opnameID, productID int
+		var c models.StockOpnameCount
+		if err := countRows.Scan(&opnameID, &productID, &c.CountedBy, &c.Quantity, &c.SystemStock, &c.CountedAt); err != nil {
+			return err
+		}
+		pos, ok := items[[2]int{opnameID, productID}]
+		if !ok {
+			continue
+		}
+		item := &opnames[pos.opname].Items[pos.item]
+		item.Counts = append(item.Counts, c)
+		counted := c.Quantity
+		if item.CountedQuantity != nil {
+			counted += *item.CountedQuantity
+		}
+		item.CountedQuantity = &counted
+		item.StockAtCount = &c.SystemStock
+	}
+	if err := countRows.Err(); err != nil {
+		return err
+	}
+
+	for i := range opnames {
+		o := &opnames[i]
+		o.Summary = models.StockOpnameSummary{Products: len(o.Items), VarianceValue: models.Rupiah(0)}
+		for j := range o.Items {
+			item := &o.Items[j]
+			item.VarianceValue = models.Rupiah(0)
+			if item.Flagged {
+				o.Summary.Flagged++
+			}
+			if item.CountedQuantity == nil {
+				o.Summary.Uncounted++
+				continue
+			}
+			item.Variance = *item.CountedQuantity - *item.StockAtCount
+			item.VarianceValue = item.CostPrice.Mul(int64(item.Variance))
+			o.Summary.Counted++
+			if item.Variance != 0 {
+				o.Summary.WithVariance++
+			}
+			o.Summary.VarianceQuantity += item.Variance
+			o.Summary.VarianceValue = o.Summary.VarianceValue.Add(item.VarianceValue)
+		}
+	}
+	return nil
+}
+
+// Create membuka sesi opname dan men-snapshot stok sistem semua produk (atau satu kategori) dalam satu statement.
+// Produk komposit dan induk varian tidak punya stok sendiri sehingga tidak ikut dihitung.
+// Satu produk hanya boleh berada di satu sesi yang sedang berjalan.
+func (r *stockOpnameRepository) Create(req models.CreateStockOpnameRequest) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Serialisasi pembukaan sesi agar dua sesi yang tumpang tindih tidak bisa dibuat bersamaan
+	if _, err := tx.Exec("LOCK TABLE stock_opnames IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return 0, err
+	}
+
+	var overlapping bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM stock_opnames
+			WHERE status = $1 AND (category_id IS NULL OR $2::int IS NULL OR category_id = $2)
+		)
+	`, models.StockOpnameStatusCounting, req.CategoryID).Scan(&overlapping)
+	if err != nil {
+		return 0, err
+	}
+	if overlapping {
+		return 0, ErrStockOpnameInProgress
+	}
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO stock_opnames (status, category_id, block_sales, note, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, models.StockOpnameStatusCounting, req.CategoryID, req.BlockSales, req.Note, req.CreatedBy).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO stock_opname_items (opname_id, product_id, expected_stock, cost_price)
+		SELECT $1, p.id, p.stock, p.cost_price FROM products p
+		WHERE p.deleted_at IS NULL AND ($2::int IS NULL OR p.category_id = $2)
+		  AND NOT `+isComposite+` AND NOT `+hasActiveVariants+`
+	`, id, req.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	if snapshot, _ := res.RowsAffected(); snapshot == 0 {
+		return 0, ErrStockOpnameEmpty
+	}
+	return id, tx.Commit()
+}
+
+// lockCountingOpname mengunci sesi opname dan memastikan statusnya masih counting.
+func lockCountingOpname(tx *sql.Tx, id int, lock string) error {
+	var status string
+	err := tx.QueryRow("SELECT status FROM stock_opnames WHERE id = $1 "+lock, id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrStockOpnameNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.StockOpnameStatusCounting {
+		return ErrStockOpnameClosed
+	}
+	return nil
+}
+
+// SubmitCounts menyimpan hasil hitung satu petugas. Hitungan ulang untuk produk yang sama menggantikan hitungan
+// petugas itu sebelumnya; hitungan petugas berbeda dijumlahkan (mis. rak depan dan gudang).
+func (r *stockOpnameRepository) SubmitCounts(id int, req models.StockOpnameCountRequest) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// FOR SHARE: beberapa petugas boleh submit bersamaan, tapi approve menunggu submit selesai
+	if err := lockCountingOpname(tx, id, "FOR SHARE"); err != nil {
+		return err
+	}
+
+	// Stok sistem ikut dicatat: barang yang terjual sebelum dihitung sudah tidak ada di rak dan sudah
+	// mengurangi stok, jadi koreksi saat approve dihitung terhadap angka ini
+	for _, c := range req.Counts {
+		res, err := tx.Exec(`
+			INSERT INTO stock_opname_counts (opname_id, product_id, counted_by, quantity, system_stock)
+			SELECT $1, $2, $3, $4, p.stock
+			FROM stock_opname_items oi
+			JOIN products p ON p.id = oi.product_id
+			WHERE oi.opname_id = $1 AND oi.product_id = $2
+			ON CONFLICT (opname_id, product_id, counted_by)
+			DO UPDATE SET quantity = EXCLUDED.quantity, system_stock = EXCLUDED.system_stock, counted_at = NOW()
+		`, id, c.ProductID, req.CountedBy, c.Quantity)
+		if err != nil {
+			return err
+		}
+		if saved, _ := res.RowsAffected(); saved == 0 {
+			return fmt.Errorf("%w: product %d", ErrProductNotInOpname, c.ProductID)
+		}
+		// Produk bisa menjadi komposit atau induk varian setelah sesi dibuka
+		if err := checkOwnStock(tx, c.ProductID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Approve menutup sesi dan memposting selisih hitung ke ledger sebagai movement opname dalam satu transaksi.
+// Selisih = jumlah hitungan - stok sistem saat hitungan terakhir disimpan, sehingga stok akhir sama dengan hasil
+// hitung ditambah pergerakan setelah barang dihitung. Produk yang belum dihitung tidak dikoreksi.
+func (r *stockOpnameRepository) Approve(id int, user string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCountingOpname(tx, id, "FOR UPDATE"); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT product_id, counted - stock_at_count
+		FROM (
+			SELECT product_id, SUM(quantity) AS counted,
+			       (ARRAY_AGG(system_stock ORDER BY counted_at DESC, id DESC))[1] AS stock_at_count
+			FROM stock_opname_counts
+			WHERE opname_id = $1
+			GROUP BY product_id
+		) c
+		WHERE counted <> stock_at_count
+		ORDER BY product_id
+	`, id)
+	if err != nil {
+		return err
+	}
+	var corrections []models.StockMovement
+	for rows.Next() {
+		m := models.StockMovement{Type: models.StockMovementOpname, ReferenceID: &id, Note: fmt.Sprintf("stock opname #%d", id), User: user}
+		if err := rows.Scan(&m.ProductID, &m.Quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		corrections = append(corrections, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Koreksi diurutkan berdasarkan product ID sehingga urutan kunci sama dengan checkout
+	for i := range corrections {
+		if err := checkOwnStock(tx, corrections[i].ProductID); err != nil {
+			return err
+		}
+		if err := changeStock(tx, &corrections[i]); err != nil {
+			if errors.Is(err, ErrInsufficientStock) {
+				return fmt.Errorf("%w for product %d", ErrInsufficientStock, corrections[i].ProductID)
+			}
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE stock_opnames SET status = $1, closed_by = $2, closed_at = NOW() WHERE id = $3
+	`, models.StockOpnameStatusApproved, user, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *stockOpnameRepository) Cancel(id int, user string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCountingOpname(tx, id, "FOR UPDATE"); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		UPDATE stock_opnames SET status = $1, closed_by = $2, closed_at = NOW() WHERE id = $3
+	`, models.StockOpnameStatusCancelled, user, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+// Penjualan selama opname berjalan tanpa block_sales: yang terjadi sebelum barang dihitung tidak boleh terpotong
+// dua kali, yang terjadi sesudahnya tetap mengurangi stok.
+func TestStockOpnameApproveWithSalesDuringCount(t *testing.T) {
+	db := testDB(t)
+	product := createTestProduct(t, db, 10)
+	transactions := NewTransactionRepository(db)
+	opnames := NewStockOpnameRepository(db)
+
+	sell := func(quantity float64) {
+		t.Helper()
+		_, err := transactions.CreateTransaction(models.CheckoutRequest{
+			Items: []models.CheckoutItem{{ProductID: product.ID, Quantity: quantity}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("checkout: %v", err)
+		}
+	}
+
+	id, err := opnames.Create(models.CreateStockOpnameRequest{CategoryID: &product.CategoryID})
+	if err != nil {
+		t.Fatalf("create opname: %v", err)
+	}
+
+	// Terjual 2 sebelum dihitung: rak berisi 8, tapi 1 unit hilang sehingga hitungan 7
+	sell(2)
+	err = opnames.SubmitCounts(id, models.StockOpnameCountRequest{
+		Counts:    []models.StockOpnameCountItem{{ProductID: product.ID, Quantity: 7}},
+		CountedBy: "tester",
+	})
+	if err != nil {
+		t.Fatalf("submit counts: %v", err)
+	}
+	// Terjual 3 setelah dihitung
+	sell(3)
+
+	opname, err := opnames.FetchByID(id)
+	if err != nil {
+		t.Fatalf("fetch opname: %v", err)
+	}
+	if item := opname.Items[0]; item.Variance != -1 || item.StockAtCount == nil || *item.StockAtCount != 8 {
+		t.Errorf("variance = %d, stock at count = %v, want -1 and 8", item.Variance, item.StockAtCount)
+	}
+
+	if err := opnames.Approve(id, "tester"); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+
+	var stock int
+	if err := db.QueryRow("SELECT stock FROM products WHERE id = $1", product.ID).Scan(&stock); err != nil {
+		t.Fatalf("read stock: %v", err)
+	}
+	if want := 7 - 3; stock != want {
+		t.Errorf("stock after approve = %d, want %d", stock, want)
+	}
+}
+
+// Produk komposit tidak masuk snapshot dan tidak bisa diberi hasil hitung.
+func TestStockOpnameSkipsComposite(t *testing.T) {
+	db := testDB(t)
+	composite, component := createTestComposite(t, db)
+	opnames := NewStockOpnameRepository(db)
+
+	// Kategori yang hanya berisi produk komposit tidak punya produk untuk dihitung
+	if _, err := opnames.Create(models.CreateStockOpnameRequest{CategoryID: &composite.CategoryID}); !errors.Is(err, ErrStockOpnameEmpty) {
+		t.Fatalf("create opname for composite category error = %v, want ErrStockOpnameEmpty", err)
+	}
+
+	id, err := opnames.Create(models.CreateStockOpnameRequest{CategoryID: &component.CategoryID})
+	if err != nil {
+		t.Fatalf("create opname: %v", err)
+	}
+	err = opnames.SubmitCounts(id, models.StockOpnameCountRequest{
+		Counts:    []models.StockOpnameCountItem{{ProductID: composite.ID, Quantity: 3}},
+		CountedBy: "tester",
+	})
+	if !errors.Is(err, ErrProductNotInOpname) {
+		t.Errorf("submit count for composite error = %v, want ErrProductNotInOpname", err)
+	}
+	if err := opnames.Cancel(id, "tester"); err != nil {
+		t.Fatalf("cancel opname: %v", err)
+	}
+}
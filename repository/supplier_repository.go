@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"time"
+)
+
+var ErrSupplierNotFound = errors.New("supplier not found")
+
+type SupplierRepository interface {
+	FetchAll() ([]models.Supplier, error)
+	FetchByID(id int) (models.Supplier, error)
+	Store(supplier *models.Supplier) error
+	Update(supplier *models.Supplier) error
+	Delete(id int) error
+}
+
+type supplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *supplierRepository {
+	return &supplierRepository{db: db}
+}
+
+const supplierColumns = `id, name, phone, email, address, note, created_at, updated_at`
+
+func scanSupplier(row rowScanner) (models.Supplier, error) {
+	var s models.Supplier
+	err := row.Scan(&s.ID, &s.Name, &s.Phone, &s.Email, &s.Address, &s.Note, &s.CreatedAt, &s.UpdatedAt)
+	return s, err
+}
+
+func (r *supplierRepository) FetchAll() ([]models.Supplier, error) {
+	rows, err := r.db.Query(`SELECT ` + supplierColumns + ` FROM suppliers WHERE deleted_at IS NULL ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]models.Supplier, 0)
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+	return suppliers, rows.Err()
+}
+
+func (r *supplierRepository) FetchByID(id int) (models.Supplier, error) {
+	s, err := scanSupplier(r.db.QueryRow(`SELECT `+supplierColumns+` FROM suppliers WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err == sql.ErrNoRows {
+		return s, ErrSupplierNotFound
+	}
+	return s, err
+}
+
+func (r *supplierRepository) Store(s *models.Supplier) error {
+	query := `
+		INSERT INTO suppliers (name, phone, email, address, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	now := time.Now()
+	if err := r.db.QueryRow(query, s.Name, s.Phone, s.Email, s.Address, s.Note, now, now).Scan(&s.ID); err != nil {
+		return err
+	}
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	return nil
+}
+
+func (r *supplierRepository) Update(s *models.Supplier) error {
+	query := `
+		UPDATE suppliers
+		SET name = $1, phone = $2, email = $3, address = $4, note = $5, updated_at = $6
+		WHERE id = $7 AND deleted_at IS NULL
+	`
+	s.UpdatedAt = time.Now()
+	res, err := r.db.Exec(query, s.Name, s.Phone, s.Email, s.Address, s.Note, s.UpdatedAt, s.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrSupplierNotFound
+	}
+	return nil
+}
+
+func (r *supplierRepository) Delete(id int) error {
+	query := `UPDATE suppliers SET deleted_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"time"
+)
+
+var ErrTaxCategoryNotFound = errors.New("tax category not found")
+
+type TaxCategoryRepository interface {
+	FetchAll() ([]models.TaxCategory, error)
+	FetchByID(id int) (models.TaxCategory, error)
+	Store(category *models.TaxCategory) error
+	Update(category *models.TaxCategory) error
+	Delete(id int) error
+}
+
+type taxCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewTaxCategoryRepository(db *sql.DB) *taxCategoryRepository {
+	return &taxCategoryRepository{db: db}
+}
+
+func (r *taxCategoryRepository) FetchAll() ([]models.TaxCategory, error) {
+	rows, err := r.db.Query(`
+		SELECT id, name, rate, description, created_at, updated_at
+		FROM tax_categories
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.TaxCategory, 0)
+	for rows.Next() {
+		var c models.TaxCategory
+		if err := rows.Scan(&c.ID, &c.Name, &c.Rate, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+func (r *taxCategoryRepository) FetchByID(id int) (models.TaxCategory, error) {
+	var c models.TaxCategory
+	err := r.db.QueryRow(`
+		SELECT id, name, rate, description, created_at, updated_at
+		FROM tax_categories
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(&c.ID, &c.Name, &c.Rate, &c.Description, &c.CreatedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return c, ErrTaxCategoryNotFound
+	}
+	return c, err
+}
+
+func (r *taxCategoryRepository) Store(c *models.TaxCategory) error {
+	query := `
+		INSERT INTO tax_categories (name, rate, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	now := time.Now()
+	err := r.db.QueryRow(query, c.Name, c.Rate, c.Description, now, now).Scan(&c.ID)
+	if err != nil {
+		return err
+	}
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	return nil
+}
+
+func (r *taxCategoryRepository) Update(c *models.TaxCategory) error {
+	query := `
+		UPDATE tax_categories
+		SET name = $1, rate = $2, description = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
+	`
+	c.UpdatedAt = time.Now()
+	res, err := r.db.Exec(query, c.Name, c.Rate, c.Description, c.UpdatedAt, c.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrTaxCategoryNotFound
+	}
+	return nil
+}
+
+func (r *taxCategoryRepository) Delete(id int) error {
+	query := `UPDATE tax_categories SET deleted_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import "kasir-api/models"
+
+// GetTaxSummary merekap DPP, PPN dan service charge per tarif untuk rentang [query.Start, query.End).
+// Refund pada periode yang sama mengurangi rekap secara proporsional terhadap total baris yang di-refund.
+func (repo *transactionRepository) GetTaxSummary(query models.ReportQuery) (models.TaxSummary, error) {
+	summary := models.TaxSummary{
+		StartDate:     query.Start,
+		EndDate:       query.End,
+		Rates:         make([]models.TaxRateSummary, 0),
+		TaxableAmount: models.Rupiah(0),
+		TaxAmount:     models.Rupiah(0),
+		ServiceCharge: models.Rupiah(0),
+		ExemptSales:   models.Rupiah(0),
+	}
+
+	rows, err := repo.db.Query(`
+		SELECT rate, SUM(taxable), SUM(tax), SUM(service)
+		FROM (
+			SELECT td.tax_rate AS rate, td.taxable_amount AS taxable, td.tax_amount AS tax, td.service_charge AS service
+			FROM transaction_details td
+			JOIN transactions t ON td.transaction_id = t.id
+			WHERE t.created_at >= $1 AND t.created_at < $2
+			UNION ALL
+			SELECT td.tax_rate,
+			       ROUND(ri.amount::numeric * td.taxable_amount / td.total),
+			       ROUND(ri.amount::numeric * td.tax_amount / td.total),
+			       ROUND(ri.amount::numeric * td.service_charge / td.total)
+			FROM transaction_refund_items ri
+			JOIN transaction_refunds r ON ri.refund_id = r.id
+			JOIN transaction_details td ON ri.transaction_detail_id = td.id
+			WHERE r.created_at >= $1 AND r.created_at < $2 AND td.total <> 0
+		) lines
+		GROUP BY rate
+		ORDER BY rate
+	`, query.Start, query.End)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.TaxRateSummary
+		if err := rows.Scan(&r.Rate, &r.TaxableAmount, &r.TaxAmount, &r.ServiceCharge); err != nil {
+			return summary, err
+		}
+		summary.Rates = append(summary.Rates, r)
+
+		summary.TaxableAmount = summary.TaxableAmount.Add(r.TaxableAmount)
+		summary.TaxAmount = summary.TaxAmount.Add(r.TaxAmount)
+		summary.ServiceCharge = summary.ServiceCharge.Add(r.ServiceCharge)
+		if r.Rate == 0 {
+			summary.ExemptSales = summary.ExemptSales.Add(r.TaxableAmount)
+		}
+	}
+	return summary, rows.Err()
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// querier dipenuhi oleh *sql.DB maupun *sql.Tx, sehingga query baca bisa dipakai di dalam transaksi.
+type querier interface {
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// Kolom yang boleh dipakai untuk sort. Prefix "-" berarti descending.
+var transactionSortColumns = map[string]string{
+	"created_at":   "t.created_at",
+	"total_amount": "t.total_amount",
+}
+
+func (repo *transactionRepository) FetchByID(id int) (*models.Transaction, error) {
+	return fetchTransaction(repo.db, id)
+}
+
+// FetchAll mengambil transaksi dengan cursor (keyset) pagination. Cursor berisi nilai kolom sort
+// dan ID baris terakhir, jadi halaman berikutnya tetap konsisten walau ada transaksi baru masuk.
+func (repo *transactionRepository) FetchAll(filter models.TransactionFilter) (models.TransactionPage, error) {
+	page := models.TransactionPage{Data: make([]models.Transaction, 0)}
+
+	sortKey := strings.TrimPrefix(filter.Sort, "-")
+	column, ok := transactionSortColumns[sortKey]
+	if !ok {
+		return page, fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+	direction, comparator := "ASC", ">"
+	if strings.HasPrefix(filter.Sort, "-") {
+		direction, comparator = "DESC", "<"
+	}
+
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, "t.created_at >= "+arg(*filter.StartDate))
+	}
+	if filter.EndDate != nil {
+		conditions = append(conditions, "t.created_at < "+arg(*filter.EndDate))
+	}
+	if filter.MinTotal != nil {
+		conditions = append(conditions, "t.total_amount >= "+arg(*filter.MinTotal))
+	}
+	if filter.MaxTotal != nil {
+		conditions = append(conditions, "t.total_amount <= "+arg(*filter.MaxTotal))
+	}
+	if filter.ProductID != 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM transaction_details td WHERE td.transaction_id = t.id AND td.product_id = "+arg(filter.ProductID)+")")
+	}
+	if filter.PaymentMethod != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM transaction_payments tp WHERE tp.transaction_id = t.id AND tp.method = "+arg(filter.PaymentMethod)+")")
+	}
+	if filter.Cashier != "" {
+		conditions = append(conditions, "t.cashier = "+arg(filter.Cashier))
+	}
+	if filter.Cursor != "" {
+		value, id, err := decodeTransactionCursor(sortKey, filter.Cursor)
+		if err != nil {
+			return page, err
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, t.id) %s (%s, %s)", column, comparator, arg(value), arg(id)))
+	}
+
+	// Ambil satu baris lebih untuk tahu apakah masih ada halaman berikutnya
+	query := fmt.Sprintf(`
+		SELECT t.id, t.subtotal, t.service_charge, t.tax_amount, t.total_amount, t.prices_include_tax, t.voucher_code,
+		       t.voucher_discount, t.paid_amount, t.change_amount, t.currency, t.status, t.cashier, t.customer, t.created_at
+		FROM transactions t
+		WHERE %s
+		ORDER BY %s %s, t.id %s
+		LIMIT %s
+	`, strings.Join(conditions, " AND "), column, direction, direction, arg(filter.Limit+1))
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Transaction
+		err := rows.Scan(&t.ID, &t.Subtotal, &t.ServiceCharge, &t.TaxAmount, &t.TotalAmount, &t.PricesIncludeTax, &t.VoucherCode,
+			&t.VoucherDiscount, &t.PaidAmount, &t.ChangeAmount, &t.Currency, &t.Status, &t.Cashier, &t.Customer, &t.CreatedAt)
+		if err != nil {
+			return page, err
+		}
+		if t.Currency, err = models.ParseCurrency(t.Currency); err != nil {
+			return page, fmt.Errorf("transaction %d: %w", t.ID, err)
+		}
+		page.Data = append(page.Data, t)
+	}
+	if err := rows.Err(); err != nil {
+		return page, err
+	}
+
+	if len(page.Data) > filter.Limit {
+		page.Data = page.Data[:filter.Limit]
+		page.NextCursor = encodeTransactionCursor(sortKey, page.Data[len(page.Data)-1])
+	}
+
+	if err := loadTransactionLines(repo.db, page.Data); err != nil {
+		return page, err
+	}
+	return page, nil
+}
+
+func fetchTransaction(q querier, id int) (*models.Transaction, error) {
+	var t models.Transaction
+	err := q.QueryRow(`
+		SELECT id, subtotal, service_charge, tax_amount, total_amount, prices_include_tax, voucher_code, voucher_discount,
+		       paid_amount, change_amount, currency, status, cashier, customer, created_at
+		FROM transactions WHERE id = $1
+	`, id).Scan(&t.ID, &t.Subtotal, &t.ServiceCharge, &t.TaxAmount, &t.TotalAmount, &t.PricesIncludeTax, &t.VoucherCode, &t.VoucherDiscount,
+		&t.PaidAmount, &t.ChangeAmount, &t.Currency, &t.Status, &t.Cashier, &t.Customer, &t.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	if t.Currency, err = models.ParseCurrency(t.Currency); err != nil {
+		return nil, fmt.Errorf("transaction %d: %w", t.ID, err)
+	}
+
+	transactions := []models.Transaction{t}
+	if err := loadTransactionLines(q, transactions); err != nil {
+		return nil, err
+	}
+	return &transactions[0], nil
+}
+
+// loadTransactionLines mengisi Details dan Payments untuk beberapa transaksi sekaligus.
+func loadTransactionLines(q querier, transactions []models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(transactions))
+	index := make(map[int]int, len(transactions))
+	for i := range transactions {
+		ids[i] = transactions[i].ID
+		index[transactions[i].ID] = i
+		transactions[i].Details = make([]models.TransactionDetail, 0)
+		transactions[i].Payments = make([]models.Payment, 0)
+	}
+
+	rows, err := q.Query(`
+		SELECT td.id, td.transaction_id, td.product_id, td.product_name, td.sku, COALESCE(td.category_id, 0), td.unit_price, td.quantity,
+		       td.discount_amount, td.voucher_discount, td.subtotal, td.service_charge, COALESCE(td.tax_category_id, 0), td.tax_exempt, td.tax_rate,
+		       td.taxable_amount, td.tax_amount, td.total, td.unit_cost, td.unit, td.unit_factor
+		FROM transaction_details td
+		WHERE td.transaction_id = ANY($1)
+		ORDER BY td.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Posisi detail (indeks transaksi, indeks detail) untuk mengisi promosi
+	type detailPos struct{ transaction, detail int }
+	details := make(map[int]detailPos)
+	for rows.Next() {
+		var d models.TransactionDetail
+		err := rows.Scan(&d.ID, &d.TransactionID, &d.ProductID, &d.ProductName, &d.SKU, &d.CategoryID, &d.UnitPrice, &d.Quantity,
+			&d.DiscountAmount, &d.VoucherDiscount, &d.Subtotal, &d.ServiceCharge, &d.TaxCategoryID, &d.TaxExempt, &d.TaxRate,
+			&d.TaxableAmount, &d.TaxAmount, &d.Total, &d.UnitCost, &d.Unit, &d.UnitFactor)
+		if err != nil {
+			return err
+		}
+		d.UnitQuantity = float64(d.Quantity) / float64(d.UnitFactor)
+		t := &transactions[index[d.TransactionID]]
+		t.Details = append(t.Details, d)
+		details[d.ID] = detailPos{index[d.TransactionID], len(t.Details) - 1}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	promotionRows, err := q.Query(`
+		SELECT tdp.transaction_detail_id, tdp.promotion_id, tdp.promotion_name, tdp.amount
+		FROM transaction_detail_promotions tdp
+		JOIN transaction_details td ON tdp.transaction_detail_id = td.id
+		WHERE td.transaction_id = ANY($1)
+		ORDER BY tdp.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer promotionRows.Close()
+
+	for promotionRows.Next() {
+		var detailID int
+		var p models.AppliedPromotion
+		if err := promotionRows.Scan(&detailID, &p.PromotionID, &p.PromotionName, &p.Amount); err != nil {
+			return err
+		}
+		pos := details[detailID]
+		d := &transactions[pos.transaction].Details[pos.detail]
+		d.Promotions = append(d.Promotions, p)
+	}
+	if err := promotionRows.Err(); err != nil {
+		return err
+	}
+
+	detailIDs := make([]int, 0, len(details))
+	for id := range details {
+		detailIDs = append(detailIDs, id)
+	}
+	components, err := fetchDetailComponents(q, detailIDs)
+	if err != nil {
+		return err
+	}
+	for detailID, used := range components {
+		pos := details[detailID]
+		transactions[pos.transaction].Details[pos.detail].Components = used
+	}
+
+	paymentRows, err := q.Query(`
+		SELECT id, transaction_id, method, amount, tendered, change, reference
+		FROM transaction_payments
+		WHERE transaction_id = ANY($1)
+		ORDER BY id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer paymentRows.Close()
+
+	for paymentRows.Next() {
+		var p models.Payment
+		if err := paymentRows.Scan(&p.ID, &p.TransactionID, &p.Method, &p.Amount, &p.Tendered, &p.Change, &p.Reference); err != nil {
+			return err
+		}
+		t := &transactions[index[p.TransactionID]]
+		t.Payments = append(t.Payments, p)
+	}
+	return paymentRows.Err()
+}
+
+func encodeTransactionCursor(sortKey string, t models.Transaction) string {
+	var value string
+	if sortKey == "created_at" {
+		value = t.CreatedAt.Format(time.RFC3339Nano)
+	} else {
+		value = strconv.FormatInt(t.TotalAmount.Amount, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value + "|" + strconv.Itoa(t.ID)))
+}
+
+func decodeTransactionCursor(sortKey, cursor string) (interface{}, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	value, rawID, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, 0, ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	if sortKey == "created_at" {
+		createdAt, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, 0, ErrInvalidCursor
+		}
+		return createdAt, id, nil
+	}
+	total, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	return total, id, nil
+}
@@ -2,13 +2,51 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"kasir-api/models"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// Lama Idempotency-Key disimpan. Setelah lewat, key yang sama dianggap request baru.
+const idempotencyKeyRetention = 24 * time.Hour
+
+// Batas percobaan ulang saat Postgres membatalkan transaksi karena serialization failure / deadlock.
+const maxTxAttempts = 3
+
+var (
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already used for a different request")
+	ErrInsufficientPayment    = errors.New("payment is less than total amount")
+	ErrTransactionNotFound    = errors.New("transaction not found")
+	ErrTransactionVoided      = errors.New("transaction already voided")
+	ErrInvalidRefund          = errors.New("invalid refund request")
+)
+
+// CheckoutPricing berisi baris checkout yang harga dan stoknya sudah dikunci, beserta voucher
+// yang dikunci FOR UPDATE jika request membawa kode voucher.
+type CheckoutPricing struct {
+	Details             []models.TransactionDetail
+	Voucher             *models.Voucher // nil jika tidak ada kode voucher atau kode tidak ditemukan
+	CustomerRedemptions int             // Jumlah pemakaian voucher oleh customer ini sebelumnya
+	VoucherDiscount     models.Money    // Diisi pricer jika voucher dipakai
+}
+
+// CheckoutPricer dipanggil di dalam transaksi database untuk mengisi diskon, voucher, service charge
+// dan pajak setiap baris sebelum total dihitung. Error dari pricer membatalkan checkout.
+type CheckoutPricer func(pricing *CheckoutPricing) error
+
 type TransactionRepository interface {
-	CreateTransaction(items []models.CheckoutItem) (*models.Transaction, error)
-	GetSalesReport(startDate, endDate string) (models.SalesReport, error)
+	CreateTransaction(req models.CheckoutRequest, pricer CheckoutPricer) (*models.Transaction, error)
+	FetchAll(filter models.TransactionFilter) (models.TransactionPage, error)
+	FetchByID(id int) (*models.Transaction, error)
+	VoidTransaction(id int, req models.VoidRequest) (*models.Refund, error)
+	CreateRefund(id int, req models.RefundRequest) (*models.Refund, error)
+	GetSalesReport(query models.ReportQuery) (models.SalesReport, error)
+	GetTaxSummary(query models.ReportQuery) (models.TaxSummary, error)
+	ResolveBarcodes(codes []string) (map[string]int, error)
 }
 
 type transactionRepository struct {
@@ -19,21 +57,77 @@ func NewTransactionRepository(db *sql.DB) *transactionRepository {
 	return &transactionRepository{db: db}
 }
 
-func (repo *transactionRepository) CreateTransaction(items []models.CheckoutItem) (*models.Transaction, error) {
+func (repo *transactionRepository) CreateTransaction(req models.CheckoutRequest, pricer CheckoutPricer) (*models.Transaction, error) {
+	var transaction *models.Transaction
+	err := withRetry(func() error {
+		var err error
+		transaction, err = repo.createTransaction(req, pricer)
+		return err
+	})
+	return transaction, err
+}
+
+func (repo *transactionRepository) createTransaction(req models.CheckoutRequest, pricer CheckoutPricer) (*models.Transaction, error) {
 	tx, err := repo.db.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	totalAmount := 0
+	if req.IdempotencyKey != "" {
+		existingID, err := claimIdempotencyKey(tx, req.IdempotencyKey, req.RequestHash)
+		if err != nil {
+			return nil, err
+		}
+		if existingID != 0 {
+			// Replay: kembalikan transaksi yang sudah pernah dibuat
+			return fetchTransaction(tx, existingID)
+		}
+	}
+
+	items := make([]models.CheckoutItem, len(req.Items))
+	copy(items, req.Items)
+
+	// Keranjang dikunci sebelum produk, urutan yang sama dengan perubahan isi keranjang.
+	// Isi keranjang dibaca ulang di dalam transaksi agar perubahan terakhir ikut terhitung.
+	if req.CartID != 0 {
+		if _, err := lockOpenCart(tx, req.CartID); err != nil {
+			return nil, err
+		}
+		if items, err = fetchCartItems(tx, req.CartID); err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, ErrCartEmpty
+		}
+	}
+
+	// Kunci baris produk selalu urut ID supaya dua checkout tidak saling deadlock. Komponen resep
+	// ikut dikunci di awal agar urutannya tetap terjaga.
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+	if err := lockSaleProducts(tx, items); err != nil {
+		return nil, err
+	}
+
 	details := make([]models.TransactionDetail, 0)
 
 	for _, item := range items {
-		var productPrice, stock int
-		var productName string
+		var productPrice, costPrice models.Money
+		var available, categoryID, taxCategoryID, parentID int
+		var productName, sku, baseUnit string
+		var taxExempt, counting, hasVariants bool
+		var taxRate float64
 
-		err := tx.QueryRow("SELECT name, price, stock FROM products WHERE id = $1", item.ProductID).Scan(&productName, &productPrice, &stock)
+		err := tx.QueryRow(`
+			SELECT p.name, COALESCE(p.sku, ''), p.price, p.cost_price, p.stock - `+reservedByOtherCarts("$2")+`,
+			       COALESCE(p.category_id, 0), COALESCE(tc.id, 0), p.tax_exempt, COALESCE(tc.rate, 0), `+underCount+`,
+			       COALESCE(p.parent_id, 0), `+hasActiveVariants+`, p.base_unit
+			FROM products p
+			LEFT JOIN tax_categories tc ON tc.id = p.tax_category_id AND tc.deleted_at IS NULL
+			WHERE p.id = $1 AND p.deleted_at IS NULL
+			FOR UPDATE OF p
+		`, item.ProductID, req.CartID).Scan(&productName, &sku, &productPrice, &costPrice, &available,
+			&categoryID, &taxCategoryID, &taxExempt, &taxRate, &counting, &parentID, &hasVariants, &baseUnit)
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("product id %d not found", item.ProductID)
 		}
@@ -41,36 +135,225 @@ func (repo *transactionRepository) CreateTransaction(items []models.CheckoutItem
 			return nil, err
 		}
 
-		if stock < item.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product %s", productName)
+		if counting {
+			return nil, fmt.Errorf("%w: %s", ErrProductUnderCount, productName)
+		}
+		if hasVariants {
+			return nil, fmt.Errorf("%w: %s", ErrVariantRequired, productName)
+		}
+		if item.ParentID != 0 && item.ParentID != parentID {
+			return nil, fmt.Errorf("%w: variant id %d, product id %d", ErrVariantMismatch, item.ProductID, item.ParentID)
 		}
 
-		subtotal := productPrice * item.Quantity
-		totalAmount += subtotal
+		unit, err := lookupSaleUnit(tx, item.ProductID, item.Unit, baseUnit, productPrice)
+		if err != nil {
+			return nil, err
+		}
+		// Item dari keranjang sudah menyimpan quantity dalam satuan dasar
+		quantity := item.BaseQuantity
+		if quantity == 0 {
+			if quantity, err = unit.baseQuantity(item.Quantity); err != nil {
+				return nil, err
+			}
+		}
 
-		_, err = tx.Exec("UPDATE products SET stock = stock - $1 WHERE id = $2", item.Quantity, item.ProductID)
+		// Produk komposit memakai stok dan harga pokok komponennya
+		components, err := fetchSaleComponents(tx, item.ProductID, req.CartID)
 		if err != nil {
 			return nil, err
 		}
+		used := make([]models.DetailComponent, 0, len(components))
+		if len(components) > 0 {
+			costPrice = models.Rupiah(0)
+		}
+		for _, c := range components {
+			need := c.quantity * quantity
+			if c.counting {
+				return nil, fmt.Errorf("%w: %s (component of %s)", ErrProductUnderCount, c.name, productName)
+			}
+			if c.available < need {
+				return nil, fmt.Errorf("%w for product %s: component %s", ErrInsufficientStock, productName, c.name)
+			}
+			costPrice = costPrice.Add(c.costPrice.Mul(int64(c.quantity)))
+			used = append(used, models.DetailComponent{ComponentID: c.id, ComponentName: c.name, Quantity: need})
+		}
+
+		// Stok yang direservasi keranjang lain tidak bisa dibeli
+		if len(components) == 0 && available < quantity {
+			return nil, fmt.Errorf("%w for product %s", ErrInsufficientStock, productName)
+		}
+
+		subtotal := unit.price.MulRatio(int64(quantity), int64(unit.factor))
 
 		details = append(details, models.TransactionDetail{
-			ProductID:   item.ProductID,
-			ProductName: productName,
-			Quantity:    item.Quantity,
-			Subtotal:    subtotal,
+			ProductID:       item.ProductID,
+			ProductName:     productName,
+			SKU:             sku,
+			CategoryID:      categoryID,
+			UnitPrice:       unit.price,
+			Quantity:        quantity,
+			Unit:            unit.name,
+			UnitFactor:      unit.factor,
+			UnitQuantity:    float64(quantity) / float64(unit.factor),
+			DiscountAmount:  models.Rupiah(0),
+			VoucherDiscount: models.Rupiah(0),
+			Subtotal:        subtotal,
+			ServiceCharge:   models.Rupiah(0),
+			TaxCategoryID:   taxCategoryID,
+			TaxExempt:       taxExempt,
+			TaxRate:         taxRate,
+			TaxableAmount:   subtotal,
+			TaxAmount:       models.Rupiah(0),
+			Total:           subtotal,
+			UnitCost:        costPrice,
+			Components:      used,
 		})
 	}
 
+	pricing := CheckoutPricing{Details: details, VoucherDiscount: models.Rupiah(0)}
+	if req.VoucherCode != "" {
+		pricing.Voucher, pricing.CustomerRedemptions, err = lockVoucher(tx, req.VoucherCode, req.Customer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if pricer != nil {
+		if err := pricer(&pricing); err != nil {
+			return nil, err
+		}
+	}
+	voucherCode := ""
+	if pricing.Voucher != nil && !pricing.VoucherDiscount.IsZero() {
+		voucherCode = pricing.Voucher.Code
+	}
+
+	subtotalAmount, serviceCharge, taxAmount, totalAmount := models.Rupiah(0), models.Rupiah(0), models.Rupiah(0), models.Rupiah(0)
+	for _, d := range details {
+		subtotalAmount = subtotalAmount.Add(d.TaxableAmount.Sub(d.ServiceCharge))
+		serviceCharge = serviceCharge.Add(d.ServiceCharge)
+		taxAmount = taxAmount.Add(d.TaxAmount)
+		totalAmount = totalAmount.Add(d.Total)
+	}
+
+	payments, err := allocatePayments(totalAmount, req.Payments)
+	if err != nil {
+		return nil, err
+	}
+	paidAmount, changeAmount := models.Rupiah(0), models.Rupiah(0)
+	for _, p := range payments {
+		paidAmount = paidAmount.Add(p.Tendered)
+		changeAmount = changeAmount.Add(p.Change)
+	}
+
 	var transactionID int
-	err = tx.QueryRow("INSERT INTO transactions (total_amount) VALUES ($1) RETURNING id", totalAmount).Scan(&transactionID)
+	var createdAt time.Time
+	err = tx.QueryRow(`
+		INSERT INTO transactions
+			(subtotal, service_charge, tax_amount, total_amount, prices_include_tax, voucher_code, voucher_discount,
+			 paid_amount, change_amount, currency, cashier, customer)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at
+	`, subtotalAmount, serviceCharge, taxAmount, totalAmount, req.PricesIncludeTax, voucherCode, pricing.VoucherDiscount,
+		paidAmount, changeAmount, totalAmount.Currency, req.Cashier, req.Customer).
+		Scan(&transactionID, &createdAt)
 	if err != nil {
 		return nil, err
 	}
 
+	// Kurangi stok setelah ID transaksi ada agar ledger bisa merujuk transaksinya. Baris produk
+	// sudah terkunci sejak awal, urutan details tetap urut product ID.
+	for _, d := range details {
+		sold := []models.StockMovement{{ProductID: d.ProductID, Quantity: -d.Quantity}}
+		if len(d.Components) > 0 {
+			sold = sold[:0]
+			for _, c := range d.Components {
+				sold = append(sold, models.StockMovement{ProductID: c.ComponentID, Quantity: -c.Quantity, Note: d.ProductName})
+			}
+		}
+		for _, movement := range sold {
+			movement.Type = models.StockMovementSale
+			movement.ReferenceID = &transactionID
+			movement.User = req.Cashier
+			movement.SellableOn = req.BusinessDate
+			if err := changeStock(tx, &movement); err != nil {
+				if errors.Is(err, ErrInsufficientStock) {
+					return nil, fmt.Errorf("%w for product %s", ErrInsufficientStock, d.ProductName)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	// Catat pemakaian voucher di transaksi yang sama, baris voucher masih terkunci
+	if voucherCode != "" {
+		_, err = tx.Exec("UPDATE vouchers SET used_count = used_count + 1 WHERE id = $1", pricing.Voucher.ID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO voucher_redemptions (voucher_id, transaction_id, customer, amount)
+			VALUES ($1, $2, $3, $4)
+		`, pricing.Voucher.ID, transactionID, req.Customer, pricing.VoucherDiscount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for i := range details {
-		details[i].TransactionID = transactionID
-		_, err = tx.Exec("INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ($1, $2, $3, $4)",
-			transactionID, details[i].ProductID, details[i].Quantity, details[i].Subtotal)
+		d := &details[i]
+		d.TransactionID = transactionID
+		err = tx.QueryRow(`
+			INSERT INTO transaction_details
+				(transaction_id, product_id, product_name, sku, category_id, unit_price, quantity, discount_amount, voucher_discount,
+				 subtotal, service_charge, tax_category_id, tax_exempt, tax_rate, taxable_amount, tax_amount, total, unit_cost, unit, unit_factor)
+			VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, $8, $9, $10, $11, NULLIF($12, 0), $13, $14, $15, $16, $17, $18, $19, $20) RETURNING id
+		`, transactionID, d.ProductID, d.ProductName, d.SKU, d.CategoryID, d.UnitPrice, d.Quantity, d.DiscountAmount, d.VoucherDiscount,
+			d.Subtotal, d.ServiceCharge, d.TaxCategoryID, d.TaxExempt, d.TaxRate, d.TaxableAmount, d.TaxAmount, d.Total, d.UnitCost,
+			d.Unit, d.UnitFactor).Scan(&d.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range d.Promotions {
+			_, err = tx.Exec(`
+				INSERT INTO transaction_detail_promotions (transaction_detail_id, promotion_id, promotion_name, amount)
+				VALUES ($1, $2, $3, $4)
+			`, d.ID, p.PromotionID, p.PromotionName, p.Amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, c := range d.Components {
+			_, err = tx.Exec(`
+				INSERT INTO transaction_detail_components (transaction_detail_id, component_id, component_name, quantity)
+				VALUES ($1, $2, $3, $4)
+			`, d.ID, c.ComponentID, c.ComponentName, c.Quantity)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range payments {
+		payments[i].TransactionID = transactionID
+		err = tx.QueryRow(`
+			INSERT INTO transaction_payments (transaction_id, method, amount, tendered, change, reference)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+		`, transactionID, payments[i].Method, payments[i].Amount, payments[i].Tendered, payments[i].Change, payments[i].Reference).Scan(&payments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.CartID != 0 {
+		_, err = tx.Exec("UPDATE carts SET status = $1, transaction_id = $2, updated_at = NOW() WHERE id = $3",
+			models.CartStatusCheckedOut, transactionID, req.CartID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.IdempotencyKey != "" {
+		_, err = tx.Exec("UPDATE checkout_idempotency_keys SET transaction_id = $1 WHERE idempotency_key = $2", transactionID, req.IdempotencyKey)
 		if err != nil {
 			return nil, err
 		}
@@ -81,45 +364,444 @@ func (repo *transactionRepository) CreateTransaction(items []models.CheckoutItem
 	}
 
 	return &models.Transaction{
-		ID:          transactionID,
-		TotalAmount: totalAmount,
-		Details:     details,
+		ID:               transactionID,
+		Subtotal:         subtotalAmount,
+		ServiceCharge:    serviceCharge,
+		TaxAmount:        taxAmount,
+		TotalAmount:      totalAmount,
+		PricesIncludeTax: req.PricesIncludeTax,
+		VoucherCode:      voucherCode,
+		VoucherDiscount:  pricing.VoucherDiscount,
+		PaidAmount:       paidAmount,
+		ChangeAmount:     changeAmount,
+		Currency:         totalAmount.Currency,
+		Status:           models.TransactionStatusCompleted,
+		Cashier:          req.Cashier,
+		Customer:         req.Customer,
+		CreatedAt:        createdAt,
+		Details:          details,
+		Payments:         payments,
 	}, nil
 }
 
-func (repo *transactionRepository) GetSalesReport(startDate, endDate string) (models.SalesReport, error) {
-	var report models.SalesReport
+// allocatePayments membagi tagihan ke setiap pembayaran (split tender). Pembayaran non-tunai
+// tidak boleh melebihi sisa tagihan; kelebihan hanya boleh dari cash dan menjadi kembalian.
+// Tanpa data pembayaran, transaksi tetap dibuat seperti sebelumnya.
+func allocatePayments(totalAmount models.Money, requests []models.PaymentRequest) ([]models.Payment, error) {
+	payments := make([]models.Payment, 0, len(requests))
+	if len(requests) == 0 {
+		return payments, nil
+	}
+
+	tendered, nonCash := models.Rupiah(0), models.Rupiah(0)
+	for _, r := range requests {
+		tendered = tendered.Add(r.Amount)
+		if r.Method != models.PaymentMethodCash {
+			nonCash = nonCash.Add(r.Amount)
+		}
+		payments = append(payments, models.Payment{
+			Method:    r.Method,
+			Amount:    r.Amount,
+			Tendered:  r.Amount,
+			Change:    models.Rupiah(0),
+			Reference: r.Reference,
+		})
+	}
+
+	if tendered.LessThan(totalAmount) {
+		return nil, fmt.Errorf("%w: paid %s of %s", ErrInsufficientPayment, tendered, totalAmount)
+	}
+	if totalAmount.LessThan(nonCash) {
+		return nil, fmt.Errorf("%w: non-cash payments exceed total amount", ErrInsufficientPayment)
+	}
+
+	// Kembalian diambil dari pembayaran cash, mulai dari yang terakhir
+	change := tendered.Sub(totalAmount)
+	for i := len(payments) - 1; i >= 0 && change.Amount > 0; i-- {
+		if payments[i].Method != models.PaymentMethodCash {
+			continue
+		}
+		c := models.Money{Amount: min(change.Amount, payments[i].Tendered.Amount), Currency: change.Currency}
+		payments[i].Change = c
+		payments[i].Amount = payments[i].Amount.Sub(c)
+		change = change.Sub(c)
+	}
+	return payments, nil
+}
+
+// withRetry menjalankan fn ulang selama error-nya bisa diulang, maksimal maxTxAttempts kali.
+func withRetry(fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = fn()
+		if !isRetryable(err) {
+			break
+		}
+	}
+	return err
+}
+
+// isRetryable mengecek error Postgres yang aman diulang: serialization_failure dan deadlock_detected.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+	return false
+}
+
+// claimIdempotencyKey mendaftarkan key untuk transaksi ini. Jika key sudah dipakai oleh
+// request yang sama, ID transaksi lamanya dikembalikan. Request lain dengan key yang sama
+// akan menunggu di INSERT sampai transaksi pertama selesai.
+func claimIdempotencyKey(tx *sql.Tx, key, requestHash string) (int, error) {
+	res, err := tx.Exec(`
+		INSERT INTO checkout_idempotency_keys (idempotency_key, request_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (idempotency_key) DO NOTHING
+	`, key, requestHash)
+	if err != nil {
+		return 0, err
+	}
+	if inserted, _ := res.RowsAffected(); inserted == 1 {
+		return 0, nil
+	}
+
+	var storedHash string
+	var transactionID sql.NullInt64
+	var createdAt time.Time
+	err = tx.QueryRow(`
+		SELECT request_hash, transaction_id, created_at
+		FROM checkout_idempotency_keys
+		WHERE idempotency_key = $1
+		FOR UPDATE
+	`, key).Scan(&storedHash, &transactionID, &createdAt)
+	if err != nil {
+		return 0, err
+	}
+
+	// Key kadaluarsa: pakai ulang untuk request baru
+	if time.Since(createdAt) > idempotencyKeyRetention || !transactionID.Valid {
+		_, err = tx.Exec(`
+			UPDATE checkout_idempotency_keys
+			SET request_hash = $1, transaction_id = NULL, created_at = NOW()
+			WHERE idempotency_key = $2
+		`, requestHash, key)
+		return 0, err
+	}
+
+	if storedHash != requestHash {
+		return 0, ErrIdempotencyKeyConflict
+	}
+	return int(transactionID.Int64), nil
+}
+
+func (repo *transactionRepository) VoidTransaction(id int, req models.VoidRequest) (*models.Refund, error) {
+	return repo.refund(id, models.RefundTypeVoid, req.Reason, req.User, nil)
+}
+
+func (repo *transactionRepository) CreateRefund(id int, req models.RefundRequest) (*models.Refund, error) {
+	return repo.refund(id, models.RefundTypeRefund, req.Reason, req.User, req.Items)
+}
+
+func (repo *transactionRepository) refund(transactionID int, refundType, reason, user string, items []models.RefundItemRequest) (*models.Refund, error) {
+	var refund *models.Refund
+	err := withRetry(func() error {
+		var err error
+		refund, err = repo.createRefund(transactionID, refundType, reason, user, items)
+		return err
+	})
+	return refund, err
+}
+
+// soldLine adalah satu baris transaction_details beserta jumlah yang sudah pernah di-refund.
+type soldLine struct {
+	detailID       int
+	productID      int
+	productName    string
+	quantity       int
+	total          models.Money // Termasuk service charge dan pajak
+	refundedQty    int
+	refundedAmount models.Money
+}
+
+// createRefund membuat record refund bernilai negatif dan mengembalikan stok. Void berarti
+// me-refund seluruh sisa quantity lalu menandai transaksi sebagai voided.
+func (repo *transactionRepository) createRefund(transactionID int, refundType, reason, user string, items []models.RefundItemRequest) (*models.Refund, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Kunci transaksi agar dua refund pada transaksi yang sama berjalan bergantian
+	var status, currency string
+	err = tx.QueryRow("SELECT status, currency FROM transactions WHERE id = $1 FOR UPDATE", transactionID).Scan(&status, &currency)
+	if err == sql.ErrNoRows {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := models.ParseCurrency(currency); err != nil {
+		return nil, fmt.Errorf("transaction %d: %w", transactionID, err)
+	}
+	if status == models.TransactionStatusVoided {
+		return nil, ErrTransactionVoided
+	}
 
-	// 1. Total Revenue & Total Transaksi
-	queryStats := `
-		SELECT COALESCE(SUM(total_amount), 0), COUNT(id)
-		FROM transactions
-		WHERE created_at >= $1 AND created_at <= $2
-	`
-	err := repo.db.QueryRow(queryStats, startDate, endDate).Scan(&report.TotalRevenue, &report.TotalTransaksi)
+	lines, err := fetchSoldLines(tx, transactionID)
 	if err != nil {
-		return report, err
+		return nil, err
 	}
 
-	// 2. Produk Terlaris
-	queryBestSeller := `
-		SELECT p.name, SUM(td.quantity) as qty
+	// Tentukan quantity yang dikembalikan per detail
+	requested := make(map[int]int)
+	order := make([]int, 0)
+	if refundType == models.RefundTypeVoid {
+		for _, l := range lines {
+			if remaining := l.quantity - l.refundedQty; remaining > 0 {
+				requested[l.detailID] = remaining
+				order = append(order, l.detailID)
+			}
+		}
+	} else {
+		for _, item := range items {
+			if item.Quantity <= 0 {
+				return nil, fmt.Errorf("%w: quantity must be greater than 0", ErrInvalidRefund)
+			}
+			if _, ok := requested[item.TransactionDetailID]; !ok {
+				order = append(order, item.TransactionDetailID)
+			}
+			requested[item.TransactionDetailID] += item.Quantity
+		}
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("%w: nothing left to refund", ErrInvalidRefund)
+	}
+
+	refund := models.Refund{
+		TransactionID: transactionID,
+		Type:          refundType,
+		Reason:        reason,
+		User:          user,
+		TotalAmount:   models.Rupiah(0),
+		Items:         make([]models.RefundItem, 0, len(order)),
+	}
+	for _, detailID := range order {
+		line, ok := lines[detailID]
+		if !ok {
+			return nil, fmt.Errorf("%w: detail id %d does not belong to transaction %d", ErrInvalidRefund, detailID, transactionID)
+		}
+		qty := requested[detailID]
+		remaining := line.quantity - line.refundedQty
+		if qty > remaining {
+			return nil, fmt.Errorf("%w: only %d of %s can be refunded", ErrInvalidRefund, remaining, line.productName)
+		}
+
+		// Sisa terakhir memakai selisih agar total refund persis sama dengan total baris
+		amount := line.total.MulRatio(int64(qty), int64(line.quantity))
+		if qty == remaining {
+			amount = line.total.Add(line.refundedAmount)
+		}
+
+		refund.Items = append(refund.Items, models.RefundItem{
+			TransactionDetailID: detailID,
+			ProductID:           line.productID,
+			ProductName:         line.productName,
+			Quantity:            qty,
+			Amount:              amount.Neg(),
+		})
+		refund.TotalAmount = refund.TotalAmount.Sub(amount)
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO transaction_refunds (transaction_id, type, reason, user_name, total_amount)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at
+	`, transactionID, refundType, reason, user, refund.TotalAmount).Scan(&refund.ID, &refund.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range refund.Items {
+		refund.Items[i].RefundID = refund.ID
+		err = tx.QueryRow(`
+			INSERT INTO transaction_refund_items (refund_id, transaction_detail_id, product_id, quantity, amount)
+			VALUES ($1, $2, $3, $4, $5) RETURNING id
+		`, refund.ID, refund.Items[i].TransactionDetailID, refund.Items[i].ProductID, refund.Items[i].Quantity, refund.Items[i].Amount).Scan(&refund.Items[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Kembalikan stok, urut product ID seperti saat checkout. Produk komposit mengembalikan
+	// stok komponen sesuai snapshot resep saat terjual.
+	components, err := fetchDetailComponents(tx, order)
+	if err != nil {
+		return nil, err
+	}
+	restock := make([]models.StockMovement, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		used, ok := components[item.TransactionDetailID]
+		if !ok {
+			restock = append(restock, models.StockMovement{ProductID: item.ProductID, Quantity: item.Quantity})
+			continue
+		}
+		soldQty := lines[item.TransactionDetailID].quantity
+		for _, c := range used {
+			restock = append(restock, models.StockMovement{ProductID: c.ComponentID, Quantity: c.Quantity / soldQty * item.Quantity})
+		}
+	}
+	sort.SliceStable(restock, func(i, j int) bool { return restock[i].ProductID < restock[j].ProductID })
+	for _, movement := range restock {
+		movement.Type = models.StockMovementRefund
+		movement.ReferenceID = &refund.ID
+		movement.Note = reason
+		movement.User = user
+		if err := changeStock(tx, &movement); err != nil {
+			return nil, err
+		}
+	}
+
+	if refundType == models.RefundTypeVoid {
+		_, err = tx.Exec("UPDATE transactions SET status = $1 WHERE id = $2", models.TransactionStatusVoided, transactionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func fetchSoldLines(tx *sql.Tx, transactionID int) (map[int]soldLine, error) {
+	rows, err := tx.Query(`
+		SELECT td.id, td.product_id, td.product_name, td.quantity, td.total,
+		       COALESCE(SUM(ri.quantity), 0), COALESCE(SUM(ri.amount), 0)
 		FROM transaction_details td
-		JOIN products p ON td.product_id = p.id
-		JOIN transactions t ON td.transaction_id = t.id
-		WHERE t.created_at >= $1 AND t.created_at <= $2
-		GROUP BY p.name
-		ORDER BY qty DESC
-		LIMIT 1
-	`
-	err = repo.db.QueryRow(queryBestSeller, startDate, endDate).Scan(&report.ProdukTerlaris.Name, &report.ProdukTerlaris.QtyTerjual)
+		LEFT JOIN transaction_refund_items ri ON ri.transaction_detail_id = td.id
+		WHERE td.transaction_id = $1
+		GROUP BY td.id
+	`, transactionID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			report.ProdukTerlaris = models.BestSellingProduct{Name: "-", QtyTerjual: 0}
-		} else {
-			return report, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make(map[int]soldLine)
+	for rows.Next() {
+		var l soldLine
+		if err := rows.Scan(&l.detailID, &l.productID, &l.productName, &l.quantity, &l.total, &l.refundedQty, &l.refundedAmount); err != nil {
+			return nil, err
+		}
+		lines[l.detailID] = l
+	}
+	return lines, rows.Err()
+}
+
+// lockSaleProducts mengunci produk yang dijual beserta komponen resepnya dalam satu query urut ID.
+func lockSaleProducts(tx *sql.Tx, items []models.CheckoutItem) error {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	rows, err := tx.Query(`
+		SELECT id FROM products
+		WHERE id = ANY($1) OR id IN (SELECT component_id FROM product_components WHERE product_id = ANY($1))
+		ORDER BY id
+		FOR UPDATE
+	`, ids)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// saleComponent adalah satu komponen resep produk komposit beserta stok yang bisa dibeli saat ini.
+type saleComponent struct {
+	id        int
+	name      string
+	quantity  int // Per satuan dasar produk komposit
+	available int
+	costPrice models.Money
+	counting  bool
+}
+
+// fetchSaleComponents mengambil resep produk; kosong berarti produk biasa. Komponen yang sudah dihapus
+// dianggap tidak bersisa.
+func fetchSaleComponents(tx *sql.Tx, productID, cartID int) ([]saleComponent, error) {
+	rows, err := tx.Query(`
+		SELECT p.id, p.name, pc.quantity,
+		       CASE WHEN p.deleted_at IS NULL THEN p.stock - `+reservedByOtherCarts("$2")+` ELSE 0 END,
+		       p.cost_price, `+underCount+`
+		FROM product_components pc
+		JOIN products p ON p.id = pc.component_id
+		WHERE pc.product_id = $1
+		ORDER BY p.id
+	`, productID, cartID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var components []saleComponent
+	for rows.Next() {
+		var c saleComponent
+		if err := rows.Scan(&c.id, &c.name, &c.quantity, &c.available, &c.costPrice, &c.counting); err != nil {
+			return nil, err
 		}
+		components = append(components, c)
 	}
+	return components, rows.Err()
+}
 
-	return report, nil
+// fetchDetailComponents mengambil snapshot komponen per transaction detail ID.
+func fetchDetailComponents(q querier, detailIDs []int) (map[int][]models.DetailComponent, error) {
+	rows, err := q.Query(`
+		SELECT transaction_detail_id, component_id, component_name, quantity
+		FROM transaction_detail_components
+		WHERE transaction_detail_id = ANY($1)
+		ORDER BY id
+	`, detailIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := make(map[int][]models.DetailComponent)
+	for rows.Next() {
+		var detailID int
+		var c models.DetailComponent
+		if err := rows.Scan(&detailID, &c.ComponentID, &c.ComponentName, &c.Quantity); err != nil {
+			return nil, err
+		}
+		components[detailID] = append(components[detailID], c)
+	}
+	return components, rows.Err()
+}
+
+// ResolveBarcodes memetakan barcode ke ID produk aktif; barcode yang tidak dikenal tidak ada di map.
+func (repo *transactionRepository) ResolveBarcodes(codes []string) (map[string]int, error) {
+	rows, err := repo.db.Query(`
+		SELECT pb.code, pb.product_id
+		FROM product_barcodes pb
+		JOIN products p ON p.id = pb.product_id AND p.deleted_at IS NULL
+		WHERE pb.code = ANY($1)
+	`, codes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make(map[string]int, len(codes))
+	for rows.Next() {
+		var code string
+		var productID int
+		if err := rows.Scan(&code, &productID); err != nil {
+			return nil, err
+		}
+		products[code] = productID
+	}
+	return products, rows.Err()
 }
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/migrations"
+	"kasir-api/models"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// testDB membuka database Postgres khusus test dari TEST_DB_CONN dan menjalankan migrasi.
+// Test dilewati jika TEST_DB_CONN tidak diisi; jangan arahkan ke database produksi.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DB_CONN")
+	if dsn == "" {
+		t.Skip("TEST_DB_CONN is not set")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := migrations.Run(db); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+	return db
+}
+
+// createTestProduct membuat kategori dan produk baru dengan stok awal lewat repository, sehingga saldo
+// awal ikut tercatat di stock_movements.
+func createTestProduct(t *testing.T, db *sql.DB, stock int) models.Product {
+	t.Helper()
+	name := fmt.Sprintf("test-%s-%d", t.Name(), time.Now().UnixNano())
+
+	var categoryID int
+	if err := db.QueryRow("INSERT INTO categories (name) VALUES ($1) RETURNING id", name).Scan(&categoryID); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	product := models.Product{Name: name, Price: models.Rupiah(1000), Stock: stock, CategoryID: categoryID}
+	if err := NewProductRepository(db).Store(&product); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	return product
+}
+
+// Checkout paralel yang memperebutkan stok terakhir: hanya sebanyak stok yang berhasil, sisanya gagal
+// karena stok tidak cukup, dan stok maupun ledger tidak pernah negatif.
+func TestCreateTransactionConcurrentLastUnits(t *testing.T) {
+	const stock, buyers = 5, 20
+
+	db := testDB(t)
+	db.SetMaxOpenConns(buyers)
+	product := createTestProduct(t, db, stock)
+	repo := NewTransactionRepository(db)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, buyers)
+	start := make(chan struct{})
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := repo.CreateTransaction(models.CheckoutRequest{
+				Items: []models.CheckoutItem{{ProductID: product.ID, Quantity: 1}},
+			}, nil)
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, ErrInsufficientStock):
+		default:
+			t.Errorf("unexpected checkout error: %v", err)
+		}
+	}
+	if succeeded != stock {
+		t.Errorf("succeeded checkouts = %d, want %d", succeeded, stock)
+	}
+
+	var finalStock, ledger int
+	err := db.QueryRow(`
+		SELECT p.stock, (SELECT COALESCE(SUM(quantity), 0) FROM stock_movements WHERE product_id = p.id)
+		FROM products p WHERE p.id = $1
+	`, product.ID).Scan(&finalStock, &ledger)
+	if err != nil {
+		t.Fatalf("read stock: %v", err)
+	}
+	if finalStock < 0 || ledger < 0 {
+		t.Errorf("stock = %d, ledger = %d, both must not be negative", finalStock, ledger)
+	}
+	if finalStock != stock-succeeded || ledger != finalStock {
+		t.Errorf("stock = %d, ledger = %d, want both %d", finalStock, ledger, stock-succeeded)
+	}
+}
+
+func TestAllocatePayments(t *testing.T) {
+	type payment struct {
+		method           string
+		amount, tendered int64
+		change           int64
+	}
+	tests := []struct {
+		name     string
+		total    int64
+		requests []models.PaymentRequest
+		want     []payment
+		wantErr  error
+	}{
+		{
+			name:  "no payments",
+			total: 35000,
+			want:  []payment{},
+		},
+		{
+			name:     "cash with change",
+			total:    35000,
+			requests: []models.PaymentRequest{{Method: models.PaymentMethodCash, Amount: models.Rupiah(50000)}},
+			want:     []payment{{models.PaymentMethodCash, 35000, 50000, 15000}},
+		},
+		{
+			name:  "split tender, change from cash",
+			total: 35000,
+			requests: []models.PaymentRequest{
+				{Method: models.PaymentMethodQRIS, Amount: models.Rupiah(20000)},
+				{Method: models.PaymentMethodCash, Amount: models.Rupiah(20000)},
+			},
+			want: []payment{{models.PaymentMethodQRIS, 20000, 20000, 0}, {models.PaymentMethodCash, 15000, 20000, 5000}},
+		},
+		{
+			name:  "change taken from last cash first",
+			total: 10000,
+			requests: []models.PaymentRequest{
+				{Method: models.PaymentMethodCash, Amount: models.Rupiah(5000)},
+				{Method: models.PaymentMethodCash, Amount: models.Rupiah(2000)},
+				{Method: models.PaymentMethodCash, Amount: models.Rupiah(5000)},
+			},
+			want: []payment{
+				{models.PaymentMethodCash, 5000, 5000, 0},
+				{models.PaymentMethodCash, 2000, 2000, 0},
+				{models.PaymentMethodCash, 3000, 5000, 2000},
+			},
+		},
+		{
+			name:     "insufficient payment",
+			total:    35000,
+			requests: []models.PaymentRequest{{Method: models.PaymentMethodCash, Amount: models.Rupiah(10000)}},
+			wantErr:  ErrInsufficientPayment,
+		},
+		{
+			name:     "non-cash over total",
+			total:    35000,
+			requests: []models.PaymentRequest{{Method: models.PaymentMethodQRIS, Amount: models.Rupiah(40000)}},
+			wantErr:  ErrInsufficientPayment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments, err := allocatePayments(models.Rupiah(tt.total), tt.requests)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("allocatePayments error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(payments) != len(tt.want) {
+				t.Fatalf("allocatePayments returned %d payments, want %d", len(payments), len(tt.want))
+			}
+			for i, p := range payments {
+				got := payment{p.Method, p.Amount.Amount, p.Tendered.Amount, p.Change.Amount}
+				if got != tt.want[i] {
+					t.Errorf("payment %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// Transaksi dengan mata uang selain mata uang toko ditolak dengan error saat dibaca, bukan panic saat dihitung.
+func TestTransactionOtherCurrencyRejected(t *testing.T) {
+	db := testDB(t)
+	product := createTestProduct(t, db, 5)
+	repo := NewTransactionRepository(db)
+
+	transaction, err := repo.CreateTransaction(models.CheckoutRequest{
+		Items: []models.CheckoutItem{{ProductID: product.ID, Quantity: 1}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if _, err := db.Exec("UPDATE transactions SET currency = 'USD' WHERE id = $1", transaction.ID); err != nil {
+		t.Fatalf("set currency: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("UPDATE transactions SET currency = 'IDR' WHERE id = $1", transaction.ID) })
+
+	if _, err := repo.FetchByID(transaction.ID); !errors.Is(err, models.ErrCurrencyMismatch) {
+		t.Errorf("FetchByID error = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := repo.CreateRefund(transaction.ID, models.RefundRequest{
+		Reason: "test",
+		Items:  []models.RefundItemRequest{{TransactionDetailID: transaction.Details[0].ID, Quantity: 1}},
+		User:   "tester",
+	}); !errors.Is(err, models.ErrCurrencyMismatch) {
+		t.Errorf("CreateRefund error = %v, want ErrCurrencyMismatch", err)
+	}
+	query := models.ReportQuery{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour), Location: time.UTC}
+	if _, err := repo.GetSalesReport(query); !errors.Is(err, models.ErrCurrencyMismatch) {
+		t.Errorf("GetSalesReport error = %v, want ErrCurrencyMismatch", err)
+	}
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrVoucherNotFound   = errors.New("voucher not found")
+	ErrVoucherCodeExists = errors.New("voucher code already exists")
+)
+
+type VoucherRepository interface {
+	FetchAll() ([]models.Voucher, error)
+	FetchByID(id int) (models.Voucher, error)
+	Store(voucher *models.Voucher) error
+	Update(voucher *models.Voucher) error
+	Delete(id int) error
+}
+
+type voucherRepository struct {
+	db *sql.DB
+}
+
+func NewVoucherRepository(db *sql.DB) *voucherRepository {
+	return &voucherRepository{db: db}
+}
+
+const voucherColumns = `
+	id, code, description, type, discount_percent, discount_amount, max_discount, min_purchase,
+	max_uses, max_uses_per_customer, used_count, active, starts_at, expires_at, created_at, updated_at
+`
+
+func scanVoucher(row rowScanner) (models.Voucher, error) {
+	var v models.Voucher
+	var startsAt, expiresAt sql.NullTime
+	err := row.Scan(
+		&v.ID, &v.Code, &v.Description, &v.Type, &v.DiscountPercent, &v.DiscountAmount, &v.MaxDiscount, &v.MinPurchase,
+		&v.MaxUses, &v.MaxUsesPerCustomer, &v.UsedCount, &v.Active, &startsAt, &expiresAt, &v.CreatedAt, &v.UpdatedAt,
+	)
+	if startsAt.Valid {
+		v.StartsAt = &startsAt.Time
+	}
+	if expiresAt.Valid {
+		v.ExpiresAt = &expiresAt.Time
+	}
+	return v, err
+}
+
+func (r *voucherRepository) FetchAll() ([]models.Voucher, error) {
+	rows, err := r.db.Query(`SELECT ` + voucherColumns + ` FROM vouchers WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vouchers := make([]models.Voucher, 0)
+	for rows.Next() {
+		v, err := scanVoucher(rows)
+		if err != nil {
+			return nil, err
+		}
+		vouchers = append(vouchers, v)
+	}
+	return vouchers, rows.Err()
+}
+
+func (r *voucherRepository) FetchByID(id int) (models.Voucher, error) {
+	v, err := scanVoucher(r.db.QueryRow(`SELECT `+voucherColumns+` FROM vouchers WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err == sql.ErrNoRows {
+		return v, ErrVoucherNotFound
+	}
+	return v, err
+}
+
+func (r *voucherRepository) Store(v *models.Voucher) error {
+	query := `
+		INSERT INTO vouchers (code, description, type, discount_percent, discount_amount, max_discount, min_purchase,
+			max_uses, max_uses_per_customer, active, starts_at, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+	now := time.Now()
+	err := r.db.QueryRow(query, v.Code, v.Description, v.Type, v.DiscountPercent, v.DiscountAmount, v.MaxDiscount, v.MinPurchase,
+		v.MaxUses, v.MaxUsesPerCustomer, v.Active, v.StartsAt, v.ExpiresAt, now, now).Scan(&v.ID)
+	if err != nil {
+		return voucherWriteError(err)
+	}
+	v.UsedCount = 0
+	v.CreatedAt = now
+	v.UpdatedAt = now
+	return nil
+}
+
+// Update tidak mengubah used_count, yang hanya bertambah lewat checkout.
+func (r *voucherRepository) Update(v *models.Voucher) error {
+	query := `
+		UPDATE vouchers
+		SET code = $1, description = $2, type = $3, discount_percent = $4, discount_amount = $5, max_discount = $6,
+			min_purchase = $7, max_uses = $8, max_uses_per_customer = $9, active = $10, starts_at = $11,
+			expires_at = $12, updated_at = $13
+		WHERE id = $14 AND deleted_at IS NULL
+	`
+	v.UpdatedAt = time.Now()
+	res, err := r.db.Exec(query, v.Code, v.Description, v.Type, v.DiscountPercent, v.DiscountAmount, v.MaxDiscount,
+		v.MinPurchase, v.MaxUses, v.MaxUsesPerCustomer, v.Active, v.StartsAt,
+		v.ExpiresAt, v.UpdatedAt, v.ID)
+	if err != nil {
+		return voucherWriteError(err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrVoucherNotFound
+	}
+	return nil
+}
+
+func (r *voucherRepository) Delete(id int) error {
+	query := `UPDATE vouchers SET deleted_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
+}
+
+// voucherWriteError mengubah unique_violation pada kode voucher menjadi ErrVoucherCodeExists.
+func voucherWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrVoucherCodeExists
+	}
+	return err
+}
+
+// lockVoucher mengunci voucher berdasarkan kode selama transaksi checkout berlangsung, sehingga
+// pemakaian voucher yang sama oleh checkout lain menunggu sampai transaksi ini selesai.
+// Mengembalikan nil jika kode tidak ditemukan.
+func lockVoucher(tx *sql.Tx, code, customer string) (*models.Voucher, int, error) {
+	v, err := scanVoucher(tx.QueryRow(`SELECT `+voucherColumns+` FROM vouchers WHERE code = $1 AND deleted_at IS NULL FOR UPDATE`, code))
+	if err == sql.ErrNoRows {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var customerRedemptions int
+	if customer != "" {
+		err = tx.QueryRow("SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = $1 AND customer = $2", v.ID, customer).
+			Scan(&customerRedemptions)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return &v, customerRedemptions, nil
+}
@@ -10,7 +10,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter(productCtrl *controller.ProductController, categoryCtrl *controller.CategoryController, transactionCtrl *controller.TransactionController) *gin.Engine {
+func SetupRouter(productCtrl *controller.ProductController, categoryCtrl *controller.CategoryController, transactionCtrl *controller.TransactionController, promotionCtrl *controller.PromotionController, taxCategoryCtrl *controller.TaxCategoryController, voucherCtrl *controller.VoucherController, cartCtrl *controller.CartController, stockCtrl *controller.StockController, supplierCtrl *controller.SupplierController, purchaseOrderCtrl *controller.PurchaseOrderController, stockOpnameCtrl *controller.StockOpnameController, batchCtrl *controller.ProductBatchController, labelCtrl *controller.LabelController) *gin.Engine {
 	r := gin.Default()
 
 	r.Use(cors.Default())
@@ -33,10 +33,99 @@ func SetupRouter(productCtrl *controller.ProductController, categoryCtrl *contro
 	r.GET("/products/:id", productCtrl.GetProductByID)
 	r.PUT("/products/:id", productCtrl.UpdateProduct)
 	r.DELETE("/products/:id", productCtrl.DeleteProduct)
+	r.PUT("/products/:id/options", productCtrl.SetProductOptions)
+	r.POST("/products/:id/variants", productCtrl.CreateProductVariant)
+	r.PUT("/products/:id/units", productCtrl.SetProductUnits)
+	r.GET("/products/:id/recipe", productCtrl.GetProductRecipe)
+	r.PUT("/products/:id/recipe", productCtrl.SetProductRecipe)
+	r.DELETE("/products/:id/recipe", productCtrl.DeleteProductRecipe)
+	r.GET("/products/barcode/:code", productCtrl.GetProductByBarcode)
+	r.POST("/products/barcodes/generate", productCtrl.GenerateMissingBarcodes)
+	r.POST("/products/:id/barcodes", productCtrl.AddProductBarcode)
+	r.DELETE("/products/:id/barcodes/:code", productCtrl.DeleteProductBarcode)
+	r.POST("/products/:id/barcodes/generate", productCtrl.GenerateProductBarcode)
+
+	// --- Stock Routes ---
+	r.GET("/products/low-stock", stockCtrl.GetLowStockProducts)
+	r.POST("/products/:id/stock-adjustments", stockCtrl.CreateStockAdjustment)
+	r.GET("/products/:id/stock-movements", stockCtrl.GetStockMovements)
+	r.GET("/stock/reconciliation", stockCtrl.GetStockReconciliation)
+
+	// --- Batch Routes ---
+	r.GET("/products/:id/batches", batchCtrl.GetProductBatches)
+	r.GET("/batches/expiring", batchCtrl.GetExpiringBatches)
+	r.POST("/batches/write-off-expired", batchCtrl.WriteOffExpiredBatches)
+	r.POST("/batches/:id/write-off", batchCtrl.WriteOffBatch)
+
+	// --- Label Routes ---
+	r.GET("/labels/layouts", labelCtrl.GetLabelLayouts)
+	r.POST("/labels", labelCtrl.RenderLabels)
+
+	// --- Stock Opname Routes ---
+	r.POST("/stock-opnames", stockOpnameCtrl.CreateStockOpname)
+	r.GET("/stock-opnames", stockOpnameCtrl.GetAllStockOpnames)
+	r.GET("/stock-opnames/:id", stockOpnameCtrl.GetStockOpnameByID)
+	r.POST("/stock-opnames/:id/counts", stockOpnameCtrl.SubmitStockOpnameCounts)
+	r.POST("/stock-opnames/:id/approve", stockOpnameCtrl.ApproveStockOpname)
+	r.POST("/stock-opnames/:id/cancel", stockOpnameCtrl.CancelStockOpname)
+
+	// --- Promotion Routes ---
+	r.GET("/promotions", promotionCtrl.GetAllPromotions)
+	r.POST("/promotions", promotionCtrl.CreatePromotion)
+	r.GET("/promotions/:id", promotionCtrl.GetPromotionByID)
+	r.PUT("/promotions/:id", promotionCtrl.UpdatePromotion)
+	r.DELETE("/promotions/:id", promotionCtrl.DeletePromotion)
+
+	// --- Tax Category Routes ---
+	r.GET("/tax-categories", taxCategoryCtrl.GetAllTaxCategories)
+	r.POST("/tax-categories", taxCategoryCtrl.CreateTaxCategory)
+	r.GET("/tax-categories/:id", taxCategoryCtrl.GetTaxCategoryByID)
+	r.PUT("/tax-categories/:id", taxCategoryCtrl.UpdateTaxCategory)
+	r.DELETE("/tax-categories/:id", taxCategoryCtrl.DeleteTaxCategory)
+
+	// --- Voucher Routes ---
+	r.GET("/vouchers", voucherCtrl.GetAllVouchers)
+	r.POST("/vouchers", voucherCtrl.CreateVoucher)
+	r.GET("/vouchers/:id", voucherCtrl.GetVoucherByID)
+	r.PUT("/vouchers/:id", voucherCtrl.UpdateVoucher)
+	r.DELETE("/vouchers/:id", voucherCtrl.DeleteVoucher)
+
+	// --- Supplier Routes ---
+	r.GET("/suppliers", supplierCtrl.GetAllSuppliers)
+	r.POST("/suppliers", supplierCtrl.CreateSupplier)
+	r.GET("/suppliers/:id", supplierCtrl.GetSupplierByID)
+	r.PUT("/suppliers/:id", supplierCtrl.UpdateSupplier)
+	r.DELETE("/suppliers/:id", supplierCtrl.DeleteSupplier)
+
+	// --- Purchase Order Routes ---
+	r.POST("/purchase-orders", purchaseOrderCtrl.CreatePurchaseOrder)
+	r.GET("/purchase-orders", purchaseOrderCtrl.GetAllPurchaseOrders)
+	r.GET("/purchase-orders/:id", purchaseOrderCtrl.GetPurchaseOrderByID)
+	r.POST("/purchase-orders/:id/cancel", purchaseOrderCtrl.CancelPurchaseOrder)
+	r.POST("/purchase-orders/:id/receipts", purchaseOrderCtrl.ReceivePurchaseOrder)
 
 	// --- Transaction Routes ---
 	r.POST("/checkout", transactionCtrl.HandleCheckout)
+	r.GET("/transactions", transactionCtrl.GetAllTransactions)
+	r.GET("/transactions/:id", transactionCtrl.GetTransactionByID)
+	r.POST("/transactions/:id/void", transactionCtrl.VoidTransaction)
+	r.POST("/transactions/:id/refunds", transactionCtrl.CreateRefund)
+
+	// --- Cart Routes ---
+	r.POST("/carts", cartCtrl.CreateCart)
+	r.GET("/carts", cartCtrl.GetAllCarts)
+	r.GET("/carts/:id", cartCtrl.GetCartByID)
+	r.DELETE("/carts/:id", cartCtrl.CancelCart)
+	r.POST("/carts/:id/items", cartCtrl.AddCartItem)
+	r.PUT("/carts/:id/items/:product_id", cartCtrl.UpdateCartItem)
+	r.DELETE("/carts/:id/items/:product_id", cartCtrl.RemoveCartItem)
+	r.POST("/carts/:id/checkout", cartCtrl.CheckoutCart)
+
+	// --- Report Routes ---
+	r.GET("/report", transactionCtrl.GetReport)
 	r.GET("/report/hari-ini", transactionCtrl.GetDailyReport)
+	r.GET("/report/tax", transactionCtrl.GetTaxSummary)
+	r.GET("/report/purchases", purchaseOrderCtrl.GetPurchaseReport)
 
 	return r
 }
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"log"
+	"time"
+)
+
+var ErrInvalidCart = errors.New("invalid cart request")
+
+type CartService struct {
+	repo         repository.CartRepository
+	transactions *TransactionService
+	ttl          time.Duration
+}
+
+func NewCartService(repo repository.CartRepository, transactions *TransactionService, ttl time.Duration) *CartService {
+	return &CartService{repo: repo, transactions: transactions, ttl: ttl}
+}
+
+func (s *CartService) Create(req models.CreateCartRequest) (*models.Cart, error) {
+	items := req.Items
+	if len(items) > 0 {
+		var err error
+		if items, err = s.transactions.resolveBarcodes(items); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCart, err)
+		}
+		if items, err = mergeCheckoutItems(items); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCart, err)
+		}
+	}
+
+	cart := models.Cart{
+		Customer:     req.Customer,
+		Cashier:      req.Cashier,
+		Note:         req.Note,
+		ReserveStock: req.ReserveStock,
+		ExpiresAt:    time.Now().Add(s.ttl),
+	}
+	if err := s.repo.Create(&cart, items); err != nil {
+		return nil, err
+	}
+	return s.GetByID(cart.ID)
+}
+
+func (s *CartService) GetAll(status string) ([]models.Cart, error) {
+	switch status {
+	case "", models.CartStatusOpen, models.CartStatusCheckedOut, models.CartStatusExpired, models.CartStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidCart, status)
+	}
+
+	carts, err := s.repo.FetchAll(status)
+	if err != nil {
+		return nil, err
+	}
+	filtered := carts[:0]
+	for i := range carts {
+		markExpired(&carts[i])
+		// Keranjang yang baru kadaluarsa belum tentu sudah dirapikan worker
+		if status == "" || carts[i].Status == status {
+			filtered = append(filtered, carts[i])
+		}
+	}
+	return filtered, nil
+}
+
+func (s *CartService) GetByID(id int) (*models.Cart, error) {
+	cart, err := s.repo.FetchByID(id)
+	if err != nil {
+		return nil, err
+	}
+	markExpired(cart)
+	return cart, nil
+}
+
+// markExpired menampilkan keranjang open yang sudah lewat masa berlakunya sebagai expired.
+func markExpired(cart *models.Cart) {
+	if cart.Status == models.CartStatusOpen && !time.Now().Before(cart.ExpiresAt) {
+		cart.Status = models.CartStatusExpired
+	}
+}
+
+// AddItem menambah quantity produk ke keranjang.
+func (s *CartService) AddItem(id int, req models.CartItemRequest) (*models.Cart, error) {
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be greater than 0", ErrInvalidCart)
+	}
+	if req.Barcode != "" {
+		items, err := s.transactions.resolveBarcodes([]models.CheckoutItem{{ProductID: req.ProductID, Barcode: req.Barcode}})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCart, err)
+		}
+		if req.ProductID != 0 && req.ProductID != items[0].VariantID {
+			return nil, fmt.Errorf("%w: barcode %s does not belong to product id %d", ErrInvalidCart, req.Barcode, req.ProductID)
+		}
+		req.ProductID = items[0].VariantID
+	}
+	if req.ProductID == 0 {
+		return nil, fmt.Errorf("%w: product_id or barcode is required", ErrInvalidCart)
+	}
+	return s.setItem(id, req.ProductID, req.Unit, req.Quantity, true)
+}
+
+// UpdateItem mengganti quantity (dan satuan) produk di keranjang, quantity 0 menghapus baris.
+func (s *CartService) UpdateItem(id, productID int, req models.CartItemQuantityRequest) (*models.Cart, error) {
+	if req.Quantity < 0 {
+		return nil, fmt.Errorf("%w: quantity must not be negative", ErrInvalidCart)
+	}
+	return s.setItem(id, productID, req.Unit, req.Quantity, false)
+}
+
+func (s *CartService) RemoveItem(id, productID int) (*models.Cart, error) {
+	return s.setItem(id, productID, "", 0, false)
+}
+
+func (s *CartService) setItem(id, productID int, unit string, quantity float64, add bool) (*models.Cart, error) {
+	if err := s.repo.SetItem(id, productID, unit, quantity, add, time.Now().Add(s.ttl)); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *CartService) Cancel(id int) error {
+	return s.repo.Cancel(id)
+}
+
+// Checkout mengubah keranjang menjadi transaksi lewat jalur checkout biasa (promosi, voucher, pajak,
+// pembayaran dan idempotency sama). Harga dikunci saat ini, bukan saat barang dimasukkan ke keranjang.
+func (s *CartService) Checkout(id int, req models.CartCheckoutRequest, cashier, idempotencyKey string) (*models.Transaction, error) {
+	cart, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if cart.Status != models.CartStatusOpen {
+		return nil, repository.ErrCartNotOpen
+	}
+	if len(cart.Items) == 0 {
+		return nil, repository.ErrCartEmpty
+	}
+
+	items := make([]models.CheckoutItem, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = models.CheckoutItem{ProductID: item.ProductID, Unit: item.Unit, Quantity: item.Quantity}
+	}
+	if cashier == "" {
+		cashier = cart.Cashier
+	}
+
+	return s.transactions.Checkout(models.CheckoutRequest{
+		Items:          items,
+		Payments:       req.Payments,
+		VoucherCode:    req.VoucherCode,
+		Customer:       cart.Customer,
+		IdempotencyKey: idempotencyKey,
+		Cashier:        cashier,
+		CartID:         cart.ID,
+	})
+}
+
+// StartExpiryWorker menandai keranjang kadaluarsa secara berkala di background.
+func (s *CartService) StartExpiryWorker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			expired, err := s.repo.ExpireCarts()
+			if err != nil {
+				log.Printf("Failed to expire carts: %v", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("Expired %d held carts", expired)
+			}
+		}
+	}()
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/barcode"
+	"kasir-api/label"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"strings"
+)
+
+// Batas permintaan label agar satu request tidak membuat dokumen raksasa
+const (
+	maxLabelCopies = 100
+	maxLabels      = 1000
+	defaultDPI     = 203 // Resolusi umum printer label thermal
+	minDPI         = 72
+	maxDPI         = 600
+)
+
+var ErrInvalidLabelRequest = errors.New("invalid label request")
+
+// LabelFile adalah hasil render label.
+type LabelFile struct {
+	ContentType string
+	Pages       int // Jumlah halaman seluruh dokumen, termasuk untuk PNG yang hanya berisi satu halaman
+	Data        []byte
+}
+
+type LabelService struct {
+	productRepo repository.ProductRepository
+}
+
+func NewLabelService(productRepo repository.ProductRepository) *LabelService {
+	return &LabelService{productRepo: productRepo}
+}
+
+func (s *LabelService) Layouts() []models.LabelSheet {
+	return label.Layouts()
+}
+
+// Render membuat label rak untuk produk yang diminta, diurutkan sesuai items dan jumlah copies.
+func (s *LabelService) Render(req models.LabelRequest) (LabelFile, error) {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidLabelRequest, msg) }
+
+	sheet, err := labelSheet(req)
+	if err != nil {
+		return LabelFile{}, err
+	}
+	if req.Format == "" {
+		req.Format = models.LabelFormatPDF
+	}
+	if req.Format != models.LabelFormatPDF && req.Format != models.LabelFormatPNG {
+		return LabelFile{}, invalid("format must be pdf or png")
+	}
+	if req.Symbology == "" {
+		req.Symbology = models.LabelSymbologyAuto
+	}
+	if req.Symbology != models.LabelSymbologyAuto && req.Symbology != models.LabelSymbologyCode128 {
+		return LabelFile{}, invalid("symbology must be auto or code128")
+	}
+	if req.DPI == 0 {
+		req.DPI = defaultDPI
+	}
+	if req.DPI < minDPI || req.DPI > maxDPI {
+		return LabelFile{}, invalid(fmt.Sprintf("dpi must be between %d and %d", minDPI, maxDPI))
+	}
+	if width, height := label.PixelSize(sheet, req.DPI); req.Format == models.LabelFormatPNG && width*height > label.MaxPixels {
+		return LabelFile{}, invalid(fmt.Sprintf("%dx%d pixel page exceeds %d pixels, lower the dpi or page size",
+			width, height, label.MaxPixels))
+	}
+	if len(req.Items) == 0 {
+		return LabelFile{}, invalid("items cannot be empty")
+	}
+
+	total := 0
+	for i := range req.Items {
+		if req.Items[i].Copies == 0 {
+			req.Items[i].Copies = 1
+		}
+		if req.Items[i].Copies < 0 || req.Items[i].Copies > maxLabelCopies {
+			return LabelFile{}, invalid(fmt.Sprintf("copies must be between 1 and %d", maxLabelCopies))
+		}
+		total += req.Items[i].Copies
+	}
+	if total > maxLabels {
+		return LabelFile{}, invalid(fmt.Sprintf("at most %d labels per request", maxLabels))
+	}
+
+	labels := make([]label.Label, 0, total)
+	products := make(map[int]models.Product)
+	for _, item := range req.Items {
+		product, ok := products[item.ProductID]
+		if !ok {
+			if product, err = s.productRepo.FetchByID(item.ProductID); err != nil {
+				return LabelFile{}, err
+			}
+			products[item.ProductID] = product
+		}
+
+		l, err := productLabel(product, item.Barcode, req.Symbology)
+		if err != nil {
+			return LabelFile{}, err
+		}
+		for n := 0; n < item.Copies; n++ {
+			labels = append(labels, l)
+		}
+	}
+
+	file := LabelFile{Pages: label.Pages(sheet, len(labels))}
+	if req.Format == models.LabelFormatPNG {
+		if req.Page == 0 {
+			req.Page = 1
+		}
+		if req.Page < 1 || req.Page > file.Pages {
+			return LabelFile{}, invalid(fmt.Sprintf("page must be between 1 and %d", file.Pages))
+		}
+		file.ContentType = "image/png"
+		file.Data, err = label.RenderPNG(sheet, labels, req.DPI, req.Page, req.Border)
+		return file, err
+	}
+	file.ContentType = "application/pdf"
+	file.Data, err = label.RenderPDF(sheet, labels, req.Border)
+	return file, err
+}
+
+// labelSheet memilih layout custom jika ada, jika tidak layout preset.
+func labelSheet(req models.LabelRequest) (models.LabelSheet, error) {
+	if req.Sheet != nil {
+		if err := label.ValidateSheet(*req.Sheet); err != nil {
+			return models.LabelSheet{}, fmt.Errorf("%w: %w", ErrInvalidLabelRequest, err)
+		}
+		return *req.Sheet, nil
+	}
+
+	name := req.Layout
+	if name == "" {
+		name = label.DefaultLayout
+	}
+	sheet, ok := label.Layout(name)
+	if !ok {
+		return models.LabelSheet{}, fmt.Errorf("%w: unknown layout %q", ErrInvalidLabelRequest, name)
+	}
+	return sheet, nil
+}
+
+// productLabel memilih kode yang dicetak: barcode yang diminta, barcode pertama produk, atau SKU.
+// Kode EAN-13/UPC dicetak sebagai EAN-13 kecuali symbology code128; kode lain selalu Code128.
+func productLabel(p models.Product, code, symbology string) (label.Label, error) {
+	var chosen *models.ProductBarcode
+	for i := range p.Barcodes {
+		if code == "" || p.Barcodes[i].Code == code {
+			chosen = &p.Barcodes[i]
+			break
+		}
+	}
+	if code != "" && chosen == nil {
+		return label.Label{}, fmt.Errorf("%w: %s on product %d", repository.ErrBarcodeNotFound, code, p.ID)
+	}
+
+	l := label.Label{Name: p.Name, Price: formatPrice(p.Price)}
+	var err error
+	switch {
+	case chosen != nil:
+		l.Code = chosen.Code
+		if symbology == models.LabelSymbologyAuto && (chosen.Type != models.BarcodeTypeInternal || barcode.ValidEAN13(chosen.Code)) {
+			l.Bars, err = barcode.EncodeEAN13(chosen.Code)
+		} else {
+			l.Bars, err = barcode.EncodeCode128(chosen.Code)
+		}
+	case p.SKU != "":
+		l.Code = p.SKU
+		l.Bars, err = barcode.EncodeCode128(p.SKU)
+	default:
+		return label.Label{}, fmt.Errorf("%w: product %d has no barcode or SKU, generate one with POST /products/%d/barcodes/generate",
+			ErrInvalidLabelRequest, p.ID, p.ID)
+	}
+	if err != nil {
+		return label.Label{}, fmt.Errorf("%w: product %d: %w", ErrInvalidLabelRequest, p.ID, err)
+	}
+	return l, nil
+}
+
+// formatPrice menulis harga untuk label dengan pemisah ribuan titik dan desimal koma, mis. "Rp15.000".
+func formatPrice(m models.Money) string {
+	whole, fraction, _ := strings.Cut(m.String(), ".")
+	sign := ""
+	if strings.HasPrefix(whole, "-") {
+		sign, whole = "-", whole[1:]
+	}
+
+	var b strings.Builder
+	for i, ch := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(ch)
+	}
+	if fraction != "" {
+		b.WriteString("," + fraction)
+	}
+
+	prefix := "Rp"
+	if m.Currency != "" && m.Currency != models.DefaultCurrency {
+		prefix = m.Currency + " "
+	}
+	return sign + prefix + b.String()
+}
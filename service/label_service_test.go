@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+// Ukuran PNG ditolak sebelum produk dibaca, jadi repository tidak diperlukan.
+func TestLabelRenderPixelLimit(t *testing.T) {
+	sheet := models.LabelSheet{PageWidth: 600, PageHeight: 600, Columns: 1, Rows: 1, LabelWidth: 50, LabelHeight: 30}
+	req := models.LabelRequest{
+		Items:  []models.LabelItem{{ProductID: 1}},
+		Format: models.LabelFormatPNG,
+		Sheet:  &sheet,
+		DPI:    maxDPI,
+	}
+	if _, err := NewLabelService(nil).Render(req); !errors.Is(err, ErrInvalidLabelRequest) {
+		t.Errorf("Render error = %v, want ErrInvalidLabelRequest", err)
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		price models.Money
+		want  string
+	}{
+		{models.Rupiah(0), "Rp0"},
+		{models.Rupiah(500), "Rp500"},
+		{models.Rupiah(15000), "Rp15.000"},
+		{models.Rupiah(1234567), "Rp1.234.567"},
+		{models.Rupiah(-15000), "-Rp15.000"},
+	}
+	for _, tt := range tests {
+		if got := formatPrice(tt.price); got != tt.want {
+			t.Errorf("formatPrice(%d) = %q, want %q", tt.price.Amount, got, tt.want)
+		}
+	}
+}
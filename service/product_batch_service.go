@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"time"
+)
+
+const maxExpiringDays = 365
+
+var ErrInvalidExpiringDays = errors.New("days must be between 0 and 365")
+
+type ProductBatchService struct {
+	repo          repository.ProductBatchRepository
+	productRepo   repository.ProductRepository
+	storeLocation *time.Location
+}
+
+func NewProductBatchService(repo repository.ProductBatchRepository, productRepo repository.ProductRepository, storeLocation *time.Location) *ProductBatchService {
+	return &ProductBatchService{repo: repo, productRepo: productRepo, storeLocation: storeLocation}
+}
+
+// today adalah tanggal hari ini di zona waktu toko.
+func (s *ProductBatchService) today() time.Time {
+	now := time.Now().In(s.storeLocation)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// markExpiry mengisi Expired dan DaysToExpiry relatif terhadap hari ini.
+func (s *ProductBatchService) markExpiry(batches []models.ProductBatch) {
+	today := s.today()
+	for i := range batches {
+		b := &batches[i]
+		if b.ExpiresAt == nil {
+			continue
+		}
+		expiresAt, err := time.Parse("2006-01-02", *b.ExpiresAt)
+		if err != nil {
+			continue
+		}
+		days := int(expiresAt.Sub(today).Hours() / 24)
+		b.DaysToExpiry = &days
+		b.Expired = days < 0
+	}
+}
+
+func (s *ProductBatchService) GetByProduct(productID int, includeEmpty bool) ([]models.ProductBatch, error) {
+	if _, err := s.productRepo.FetchByID(productID); err != nil {
+		return nil, err
+	}
+	batches, err := s.repo.FetchByProduct(productID, includeEmpty)
+	if err != nil {
+		return nil, err
+	}
+	s.markExpiry(batches)
+	return batches, nil
+}
+
+// GetExpiring mengambil batch yang kadaluarsa dalam days hari ke depan, termasuk yang sudah kadaluarsa.
+func (s *ProductBatchService) GetExpiring(days int) ([]models.ProductBatch, error) {
+	if days < 0 || days > maxExpiringDays {
+		return nil, ErrInvalidExpiringDays
+	}
+	until := s.today().AddDate(0, 0, days).Format("2006-01-02")
+	batches, err := s.repo.FetchExpiring(until)
+	if err != nil {
+		return nil, err
+	}
+	s.markExpiry(batches)
+	return batches, nil
+}
+
+func (s *ProductBatchService) WriteOff(batchID int, req models.BatchWriteOffRequest) (*models.StockMovement, error) {
+	return s.repo.WriteOff(batchID, s.today().Format("2006-01-02"), req)
+}
+
+func (s *ProductBatchService) WriteOffExpired(req models.BatchWriteOffRequest) ([]models.StockMovement, error) {
+	return s.repo.WriteOffExpired(s.today().Format("2006-01-02"), req)
+}
@@ -1,8 +1,20 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"kasir-api/barcode"
 	"kasir-api/models"
 	"kasir-api/repository"
+	"strings"
+)
+
+var (
+	ErrInvalidProductOptions = errors.New("invalid product options")
+	ErrInvalidVariant        = errors.New("invalid variant")
+	ErrInvalidProductUnits   = errors.New("invalid product units")
+	ErrProductNotComposite   = errors.New("product has no recipe")
+	ErrInvalidBarcode        = errors.New("invalid barcode")
 )
 
 type ProductService struct {
@@ -17,15 +29,50 @@ func (s *ProductService) GetAll(name string) ([]models.Product, error) {
 	return s.repo.FetchAll(name)
 }
 
+// GetByID mengembalikan produk beserta satuannya, serta jenis opsi dan matriks variannya jika produk ini induk varian.
 func (s *ProductService) GetByID(id int) (models.Product, error) {
-	return s.repo.FetchByID(id)
+	product, err := s.repo.FetchByID(id)
+	if err != nil {
+		return product, err
+	}
+	if product.Units, err = s.repo.FetchUnits(id); err != nil {
+		return models.Product{}, err
+	}
+	if product.Composite {
+		if product.Components, err = s.repo.FetchComponents(id); err != nil {
+			return models.Product{}, err
+		}
+	}
+	if product.ParentID != nil {
+		return product, nil
+	}
+
+	if product.Options, err = s.repo.FetchOptions(id); err != nil {
+		return models.Product{}, err
+	}
+	if product.Variants, err = s.repo.FetchVariants(id); err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
 }
 
 func (s *ProductService) Create(input *models.Product) error {
+	input.SKU = strings.TrimSpace(input.SKU)
+	for i := range input.Barcodes {
+		if err := normalizeBarcode(&input.Barcodes[i]); err != nil {
+			return err
+		}
+	}
+	input.BaseUnit = strings.TrimSpace(input.BaseUnit)
+	if input.BaseUnit == "" {
+		input.BaseUnit = models.DefaultBaseUnit
+	}
 	return s.repo.Store(input)
 }
 
-func (s *ProductService) Update(id int, input models.Product) (models.Product, error) {
+// Update tidak mengubah stok kecuali setStock true. Perubahan stok sehari-hari sebaiknya lewat
+// StockService.Adjust agar tidak menimpa pengurangan stok dari penjualan yang berjalan bersamaan.
+func (s *ProductService) Update(id int, input models.Product, setStock bool) (models.Product, error) {
 	existingProduct, err := s.repo.FetchByID(id)
 	if err != nil {
 		return models.Product{}, err
@@ -33,15 +80,31 @@ func (s *ProductService) Update(id int, input models.Product) (models.Product, e
 
 	// Update field
 	existingProduct.Name = input.Name
+	existingProduct.SKU = strings.TrimSpace(input.SKU)
 	existingProduct.Price = input.Price
-	existingProduct.Stock = input.Stock
+	existingProduct.CostPrice = input.CostPrice
+	if setStock {
+		existingProduct.Stock = input.Stock
+	}
+	existingProduct.TaxCategoryID = input.TaxCategoryID
+	existingProduct.TaxExempt = input.TaxExempt
+	existingProduct.ReorderPoint = input.ReorderPoint
+	existingProduct.ReorderQuantity = input.ReorderQuantity
+
+	// Stok tersimpan dalam satuan dasar, jadi satuan dasar hanya boleh diganti selagi stok kosong
+	if baseUnit := strings.TrimSpace(input.BaseUnit); baseUnit != "" && baseUnit != existingProduct.BaseUnit {
+		if existingProduct.Stock != 0 {
+			return models.Product{}, fmt.Errorf("%w: base_unit can only be changed while stock is 0", ErrInvalidProductUnits)
+		}
+		existingProduct.BaseUnit = baseUnit
+	}
 
 	// Cek jika category ID berubah
 	if input.CategoryID != 0 {
 		existingProduct.CategoryID = input.CategoryID
 	}
 
-	err = s.repo.Update(&existingProduct)
+	err = s.repo.Update(&existingProduct, setStock)
 	if err != nil {
 		return models.Product{}, err
 	}
@@ -56,3 +119,267 @@ func (s *ProductService) Delete(id int) error {
 	}
 	return s.repo.Delete(id)
 }
+
+// SetOptions mengganti jenis opsi produk induk. Varian yang sudah ada harus tetap cocok dengan opsi baru.
+func (s *ProductService) SetOptions(id int, options []models.ProductOption) (models.Product, error) {
+	names := make(map[string]bool, len(options))
+	for i := range options {
+		o := &options[i]
+		o.Name = strings.TrimSpace(o.Name)
+		if o.Name == "" || names[o.Name] {
+			return models.Product{}, fmt.Errorf("%w: option names must be non-empty and unique", ErrInvalidProductOptions)
+		}
+		names[o.Name] = true
+
+		if len(o.Values) == 0 {
+			return models.Product{}, fmt.Errorf("%w: option %s has no values", ErrInvalidProductOptions, o.Name)
+		}
+		values := make(map[string]bool, len(o.Values))
+		for j := range o.Values {
+			o.Values[j] = strings.TrimSpace(o.Values[j])
+			if o.Values[j] == "" || values[o.Values[j]] {
+				return models.Product{}, fmt.Errorf("%w: values of option %s must be non-empty and unique", ErrInvalidProductOptions, o.Name)
+			}
+			values[o.Values[j]] = true
+		}
+	}
+
+	variants, err := s.repo.FetchVariants(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	for _, v := range variants {
+		if err := matchOptions(options, v.OptionValues); err != nil {
+			return models.Product{}, fmt.Errorf("%w: variant %s no longer matches: %v", ErrInvalidProductOptions, v.Name, err)
+		}
+	}
+
+	if err := s.repo.SetOptions(id, options); err != nil {
+		return models.Product{}, err
+	}
+	return s.GetByID(id)
+}
+
+// CreateVariant menambah varian ke produk induk. Nama varian dibentuk dari nama induk dan nilai opsinya,
+// mis. "Kaos Polos / Merah / L".
+func (s *ProductService) CreateVariant(parentID int, input models.CreateVariantRequest) (models.Product, error) {
+	parent, err := s.repo.FetchByID(parentID)
+	if err != nil {
+		return models.Product{}, err
+	}
+	options, err := s.repo.FetchOptions(parentID)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if len(options) == 0 {
+		return models.Product{}, fmt.Errorf("%w: set product options first", ErrInvalidVariant)
+	}
+	if input.Price.IsNegative() || input.CostPrice.IsNegative() || input.Stock < 0 {
+		return models.Product{}, fmt.Errorf("%w: price, cost_price and stock must not be negative", ErrInvalidVariant)
+	}
+
+	values := make(models.OptionSet, len(input.OptionValues))
+	for k, v := range input.OptionValues {
+		values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	if err := matchOptions(options, values); err != nil {
+		return models.Product{}, fmt.Errorf("%w: %v", ErrInvalidVariant, err)
+	}
+
+	name := []string{parent.Name}
+	for _, o := range options {
+		name = append(name, values[o.Name])
+	}
+	variant := models.Product{
+		Name:         strings.Join(name, " / "),
+		SKU:          input.SKU,
+		Price:        input.Price,
+		CostPrice:    input.CostPrice,
+		Stock:        input.Stock,
+		OptionValues: values,
+	}
+	if code := strings.TrimSpace(input.Barcode); code != "" {
+		b := models.ProductBarcode{Code: code}
+		if err := normalizeBarcode(&b); err != nil {
+			return models.Product{}, err
+		}
+		variant.Barcodes = []models.ProductBarcode{b}
+	}
+	if err := s.repo.CreateVariant(parentID, &variant); err != nil {
+		return models.Product{}, err
+	}
+	return s.repo.FetchByID(variant.ID)
+}
+
+// matchOptions memastikan values berisi tepat satu nilai yang diizinkan untuk setiap opsi.
+func matchOptions(options []models.ProductOption, values models.OptionSet) error {
+	if len(values) != len(options) {
+		return fmt.Errorf("option_values must have exactly %d option(s)", len(options))
+	}
+	for _, o := range options {
+		value, ok := values[o.Name]
+		if !ok {
+			return fmt.Errorf("missing value for option %s", o.Name)
+		}
+		allowed := false
+		for _, v := range o.Values {
+			if v == value {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("value %q is not allowed for option %s", value, o.Name)
+		}
+	}
+	return nil
+}
+
+// SetUnits mengganti satuan tambahan produk, mis. pack = 6 pcs dan dus = 24 pcs.
+func (s *ProductService) SetUnits(id int, units []models.ProductUnit) (models.Product, error) {
+	product, err := s.repo.FetchByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	names := map[string]bool{product.BaseUnit: true}
+	for i := range units {
+		u := &units[i]
+		u.Unit = strings.TrimSpace(u.Unit)
+		if u.Unit == "" || names[u.Unit] {
+			return models.Product{}, fmt.Errorf("%w: unit names must be non-empty, unique and differ from base_unit %s", ErrInvalidProductUnits, product.BaseUnit)
+		}
+		names[u.Unit] = true
+
+		if u.Factor <= 0 {
+			return models.Product{}, fmt.Errorf("%w: factor of %s must be greater than 0", ErrInvalidProductUnits, u.Unit)
+		}
+		if u.Price.IsNegative() {
+			return models.Product{}, fmt.Errorf("%w: price of %s must not be negative", ErrInvalidProductUnits, u.Unit)
+		}
+		// Harga 0 bukan berarti gratis: satuan dijual seharga isinya dalam satuan dasar
+		if u.Price.IsZero() {
+			u.Price = product.Price.Mul(int64(u.Factor))
+		}
+		if u.Sellable && u.Price.IsZero() {
+			return models.Product{}, fmt.Errorf("%w: sellable unit %s needs a price greater than 0", ErrInvalidProductUnits, u.Unit)
+		}
+		if u.Fractional && u.Factor == 1 {
+			return models.Product{}, fmt.Errorf("%w: fractional unit %s needs a factor greater than 1", ErrInvalidProductUnits, u.Unit)
+		}
+	}
+
+	if err := s.repo.SetUnits(id, units); err != nil {
+		return models.Product{}, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *ProductService) GetRecipe(id int) ([]models.ProductComponent, error) {
+	product, err := s.repo.FetchByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !product.Composite {
+		return nil, ErrProductNotComposite
+	}
+	return s.repo.FetchComponents(id)
+}
+
+// SetRecipe menjadikan produk komposit (paket / racikan). Penjualannya mengurangi stok setiap komponen
+// sebanyak quantity resep dikali quantity terjual.
+func (s *ProductService) SetRecipe(id int, components []models.ProductComponent) (models.Product, error) {
+	if len(components) == 0 {
+		return models.Product{}, fmt.Errorf("%w: components is empty, use DELETE to remove the recipe", repository.ErrInvalidRecipe)
+	}
+	seen := make(map[int]bool, len(components))
+	for _, c := range components {
+		if c.ComponentID == id || seen[c.ComponentID] {
+			return models.Product{}, fmt.Errorf("%w: components must be unique and differ from the product itself", repository.ErrInvalidRecipe)
+		}
+		if c.Quantity <= 0 {
+			return models.Product{}, fmt.Errorf("%w: quantity of component id %d must be greater than 0", repository.ErrInvalidRecipe, c.ComponentID)
+		}
+		seen[c.ComponentID] = true
+	}
+
+	if err := s.repo.SetComponents(id, components); err != nil {
+		return models.Product{}, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *ProductService) DeleteRecipe(id int) error {
+	if _, err := s.GetRecipe(id); err != nil {
+		return err
+	}
+	return s.repo.SetComponents(id, nil)
+}
+
+// GetByCode mencari produk dari hasil scan barcode atau SKU.
+func (s *ProductService) GetByCode(code string) (models.Product, error) {
+	product, err := s.repo.FetchByCode(strings.TrimSpace(code))
+	if err != nil {
+		return product, err
+	}
+	return s.GetByID(product.ID)
+}
+
+func (s *ProductService) AddBarcode(productID int, input models.ProductBarcode) (models.ProductBarcode, error) {
+	if err := normalizeBarcode(&input); err != nil {
+		return models.ProductBarcode{}, err
+	}
+	input.ProductID = productID
+	if err := s.repo.AddBarcode(&input); err != nil {
+		return models.ProductBarcode{}, err
+	}
+	return input, nil
+}
+
+func (s *ProductService) DeleteBarcode(productID int, code string) error {
+	return s.repo.DeleteBarcode(productID, code)
+}
+
+// GenerateBarcodes membuat barcode internal untuk produk tanpa barcode; productID 0 berarti semua produk.
+func (s *ProductService) GenerateBarcodes(productID int) ([]models.ProductBarcode, error) {
+	if productID != 0 {
+		if _, err := s.repo.FetchByID(productID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.GenerateInternalBarcodes(productID)
+}
+
+// normalizeBarcode menebak jenis barcode dari panjangnya jika kosong (13 digit EAN-13, 12 digit UPC-A,
+// selain itu internal) lalu memvalidasi formatnya, termasuk digit cek EAN / UPC.
+func normalizeBarcode(b *models.ProductBarcode) error {
+	b.Code = strings.TrimSpace(b.Code)
+	if b.Type == "" {
+		switch {
+		case len(b.Code) == 13 && strings.Trim(b.Code, "0123456789") == "":
+			b.Type = models.BarcodeTypeEAN13
+		case len(b.Code) == 12 && strings.Trim(b.Code, "0123456789") == "":
+			b.Type = models.BarcodeTypeUPC
+		default:
+			b.Type = models.BarcodeTypeInternal
+		}
+	}
+
+	switch b.Type {
+	case models.BarcodeTypeEAN13:
+		if !barcode.ValidEAN13(b.Code) {
+			return fmt.Errorf("%w: %s is not a valid EAN-13, check the digits and check digit", ErrInvalidBarcode, b.Code)
+		}
+	case models.BarcodeTypeUPC:
+		if !barcode.ValidUPC(b.Code) {
+			return fmt.Errorf("%w: %s is not a valid UPC-A, check the digits and check digit", ErrInvalidBarcode, b.Code)
+		}
+	case models.BarcodeTypeInternal:
+		if !barcode.ValidInternal(b.Code) {
+			return fmt.Errorf("%w: internal code must be 1-48 printable ASCII characters", ErrInvalidBarcode)
+		}
+	default:
+		return fmt.Errorf("%w: type must be ean13, upc or internal", ErrInvalidBarcode)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"testing"
+)
+
+// fakeProductRepo hanya mengimplementasikan method yang dipakai SetUnits dan GetByID; method lain panic karena nil.
+type fakeProductRepo struct {
+	repository.ProductRepository
+	product models.Product
+	units   []models.ProductUnit
+}
+
+func (r *fakeProductRepo) FetchByID(id int) (models.Product, error) {
+	if id != r.product.ID {
+		return models.Product{}, repository.ErrProductNotFound
+	}
+	return r.product, nil
+}
+
+func (r *fakeProductRepo) FetchUnits(int) ([]models.ProductUnit, error)     { return r.units, nil }
+func (r *fakeProductRepo) FetchOptions(int) ([]models.ProductOption, error) { return nil, nil }
+func (r *fakeProductRepo) FetchVariants(int) ([]models.Product, error)      { return nil, nil }
+
+func (r *fakeProductRepo) SetUnits(_ int, units []models.ProductUnit) error {
+	r.units = units
+	return nil
+}
+
+func TestProductSetUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     int64
+		units     []models.ProductUnit
+		wantPrice []int64
+		wantErr   error
+	}{
+		{
+			name:      "explicit price",
+			price:     2500,
+			units:     []models.ProductUnit{{Unit: "dus", Factor: 24, Price: models.Rupiah(55000), Sellable: true}},
+			wantPrice: []int64{55000},
+		},
+		{
+			name:      "zero price is base price times factor",
+			price:     2500,
+			units:     []models.ProductUnit{{Unit: "dus", Factor: 24, Sellable: true}, {Unit: "pack", Factor: 6, Price: models.Rupiah(0), Sellable: true}},
+			wantPrice: []int64{60000, 15000},
+		},
+		{
+			name:    "free product cannot get a free sellable unit",
+			price:   0,
+			units:   []models.ProductUnit{{Unit: "dus", Factor: 24, Sellable: true}},
+			wantErr: ErrInvalidProductUnits,
+		},
+		{
+			name:      "purchase-only unit may stay without price",
+			price:     0,
+			units:     []models.ProductUnit{{Unit: "dus", Factor: 24}},
+			wantPrice: []int64{0},
+		},
+		{
+			name:    "negative price",
+			price:   2500,
+			units:   []models.ProductUnit{{Unit: "dus", Factor: 24, Price: models.Rupiah(-1), Sellable: true}},
+			wantErr: ErrInvalidProductUnits,
+		},
+		{
+			name:    "zero factor",
+			price:   2500,
+			units:   []models.ProductUnit{{Unit: "dus", Price: models.Rupiah(60000), Sellable: true}},
+			wantErr: ErrInvalidProductUnits,
+		},
+		{
+			name:    "same name as base unit",
+			price:   2500,
+			units:   []models.ProductUnit{{Unit: "pcs", Factor: 2, Price: models.Rupiah(5000), Sellable: true}},
+			wantErr: ErrInvalidProductUnits,
+		},
+		{
+			name:    "fractional unit with factor 1",
+			price:   2500,
+			units:   []models.ProductUnit{{Unit: "biji", Factor: 1, Price: models.Rupiah(2500), Sellable: true, Fractional: true}},
+			wantErr: ErrInvalidProductUnits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProductRepo{product: models.Product{ID: 1, Price: models.Rupiah(tt.price), BaseUnit: "pcs"}}
+			product, err := NewProductService(repo).SetUnits(1, tt.units)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetUnits error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(product.Units) != len(tt.wantPrice) {
+				t.Fatalf("saved %d units, want %d", len(product.Units), len(tt.wantPrice))
+			}
+			for i, u := range product.Units {
+				if u.Price.Amount != tt.wantPrice[i] {
+					t.Errorf("unit %s price = %d, want %d", u.Unit, u.Price.Amount, tt.wantPrice[i])
+				}
+			}
+		})
+	}
+}
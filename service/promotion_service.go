@@ -0,0 +1,327 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"slices"
+	"sort"
+	"time"
+)
+
+var ErrInvalidPromotion = errors.New("invalid promotion")
+
+type PromotionService struct {
+	repo repository.PromotionRepository
+}
+
+func NewPromotionService(repo repository.PromotionRepository) *PromotionService {
+	return &PromotionService{repo: repo}
+}
+
+func (s *PromotionService) GetAll() ([]models.Promotion, error) {
+	return s.repo.FetchAll()
+}
+
+func (s *PromotionService) GetByID(id int) (models.Promotion, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *PromotionService) Create(input *models.Promotion) error {
+	if err := validatePromotion(input); err != nil {
+		return err
+	}
+	return s.repo.Store(input)
+}
+
+func (s *PromotionService) Update(id int, input models.Promotion) (models.Promotion, error) {
+	if _, err := s.repo.FetchByID(id); err != nil {
+		return models.Promotion{}, err
+	}
+	if err := validatePromotion(&input); err != nil {
+		return models.Promotion{}, err
+	}
+
+	input.ID = id
+	if err := s.repo.Update(&input); err != nil {
+		return models.Promotion{}, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *PromotionService) Delete(id int) error {
+	_, err := s.repo.FetchByID(id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+func validatePromotion(p *models.Promotion) error {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidPromotion, msg) }
+	hasTarget := p.ProductID != nil || p.CategoryID != nil
+	validPercent := p.DiscountPercent > 0 && p.DiscountPercent <= 100
+
+	switch p.Type {
+	case models.PromotionTypePercentage:
+		if !hasTarget || !validPercent {
+			return invalid("percentage needs product_id or category_id and discount_percent between 0 and 100")
+		}
+	case models.PromotionTypeFixed:
+		if !hasTarget || p.DiscountAmount.Amount <= 0 {
+			return invalid("fixed needs product_id or category_id and a positive discount_amount")
+		}
+	case models.PromotionTypeBuyXGetY:
+		if !hasTarget || p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return invalid("buy_x_get_y needs product_id or category_id, buy_quantity and get_quantity")
+		}
+	case models.PromotionTypeBundle:
+		if len(p.BundleItems) == 0 || p.BundlePrice.Amount <= 0 {
+			return invalid("bundle needs bundle_items and a positive bundle_price")
+		}
+		for _, item := range p.BundleItems {
+			if item.Quantity <= 0 {
+				return invalid("bundle item quantity must be greater than 0")
+			}
+		}
+	case models.PromotionTypeMinSpend:
+		if p.MinSpend.Amount <= 0 || validPercent == (p.DiscountAmount.Amount > 0) {
+			return invalid("min_spend needs min_spend and either discount_percent or discount_amount")
+		}
+	default:
+		return invalid("unknown type " + p.Type)
+	}
+
+	if (p.StartTime == "") != (p.EndTime == "") {
+		return invalid("start_time and end_time must be set together")
+	}
+	for _, t := range []string{p.StartTime, p.EndTime} {
+		if _, err := time.Parse("15:04", t); t != "" && err != nil {
+			return invalid("start_time and end_time must be HH:MM")
+		}
+	}
+	for _, d := range p.DaysOfWeek {
+		if d < 0 || d > 6 {
+			return invalid("days_of_week must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.StartsAt.Before(*p.EndsAt) {
+		return invalid("starts_at must be before ends_at")
+	}
+	return nil
+}
+
+// inSchedule mengecek jam dan hari berlaku promo (happy hour) pada waktu lokal toko.
+// Jendela yang melewati tengah malam (mis. 22:00-02:00) juga didukung.
+func inSchedule(p models.Promotion, local time.Time) bool {
+	if len(p.DaysOfWeek) > 0 && !slices.Contains(p.DaysOfWeek, int(local.Weekday())) {
+		return false
+	}
+	if p.StartTime == "" {
+		return true
+	}
+	now := local.Format("15:04")
+	if p.StartTime <= p.EndTime {
+		return now >= p.StartTime && now < p.EndTime
+	}
+	return now >= p.StartTime || now < p.EndTime
+}
+
+// promotionEngine menghitung diskon untuk baris checkout. Urutan evaluasi:
+//  1. bundle, baris yang masuk paket tidak mendapat promo baris lain;
+//  2. promo baris (percentage, fixed, buy_x_get_y), dipilih satu yang potongannya terbesar;
+//  3. promo keranjang (min_spend) terbaik, dibagi proporsional ke setiap baris.
+type promotionEngine struct {
+	promotions []models.Promotion
+}
+
+type pricedLine struct {
+	detail    *models.TransactionDetail
+	gross     models.Money
+	bundled   bool
+	remaining int // Unit yang belum masuk bundle
+	discounts []models.AppliedPromotion
+}
+
+func (l *pricedLine) net() models.Money {
+	net := l.gross
+	for _, d := range l.discounts {
+		net = net.Sub(d.Amount)
+	}
+	return net
+}
+
+func (e promotionEngine) apply(details []models.TransactionDetail) error {
+	lines := make([]*pricedLine, len(details))
+	byProduct := make(map[int]*pricedLine, len(details))
+	for i := range details {
+		d := &details[i]
+		lines[i] = &pricedLine{detail: d, gross: d.PriceFor(d.Quantity), remaining: d.Quantity}
+		byProduct[d.ProductID] = lines[i]
+	}
+
+	for _, p := range e.promotions {
+		if p.Type == models.PromotionTypeBundle {
+			applyBundle(p, byProduct)
+		}
+	}
+
+	for _, l := range lines {
+		if l.bundled {
+			continue
+		}
+		var best *models.AppliedPromotion
+		for _, p := range e.promotions {
+			amount, ok := lineDiscount(p, l)
+			if ok && amount.Amount > 0 && (best == nil || best.Amount.LessThan(amount)) {
+				best = &models.AppliedPromotion{PromotionID: p.ID, PromotionName: p.Name, Amount: amount}
+			}
+		}
+		if best != nil {
+			l.discounts = append(l.discounts, *best)
+		}
+	}
+
+	applyCartPromotion(e.promotions, lines)
+
+	for _, l := range lines {
+		l.detail.Promotions = l.discounts
+		l.detail.DiscountAmount = l.gross.Sub(l.net())
+		l.detail.Subtotal = l.net()
+	}
+	return nil
+}
+
+func applyBundle(p models.Promotion, byProduct map[int]*pricedLine) {
+	sets := -1
+	setValue := models.Rupiah(0)
+	for _, item := range p.BundleItems {
+		l, ok := byProduct[item.ProductID]
+		if !ok {
+			return
+		}
+		if n := l.remaining / item.Quantity; sets == -1 || n < sets {
+			sets = n
+		}
+		setValue = setValue.Add(l.detail.PriceFor(item.Quantity))
+	}
+	if sets <= 0 || !p.BundlePrice.LessThan(setValue) {
+		return
+	}
+
+	// Potongan per paket dibagi ke setiap produk sesuai porsi harganya
+	discount := setValue.Sub(p.BundlePrice).Mul(int64(sets))
+	weights := make([]models.Money, len(p.BundleItems))
+	for i, item := range p.BundleItems {
+		weights[i] = byProduct[item.ProductID].detail.PriceFor(item.Quantity)
+	}
+	for i, amount := range allocate(discount, weights) {
+		l := byProduct[p.BundleItems[i].ProductID]
+		l.remaining -= p.BundleItems[i].Quantity * sets
+		l.bundled = true
+		l.discounts = append(l.discounts, models.AppliedPromotion{PromotionID: p.ID, PromotionName: p.Name, Amount: amount})
+	}
+}
+
+// lineDiscount menghitung potongan promo baris p untuk baris l. ok bernilai false jika promo tidak berlaku.
+func lineDiscount(p models.Promotion, l *pricedLine) (models.Money, bool) {
+	d := l.detail
+	if p.ProductID != nil && *p.ProductID != d.ProductID {
+		return models.Money{}, false
+	}
+	if p.CategoryID != nil && *p.CategoryID != d.CategoryID {
+		return models.Money{}, false
+	}
+
+	switch p.Type {
+	case models.PromotionTypePercentage:
+		return l.gross.MulRatio(percentBasisPoints(p.DiscountPercent), 10000), true
+	case models.PromotionTypeFixed:
+		amount := p.DiscountAmount.Mul(int64(d.Quantity))
+		if l.gross.LessThan(amount) {
+			amount = l.gross
+		}
+		return amount, true
+	case models.PromotionTypeBuyXGetY:
+		free := d.Quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		return d.PriceFor(free), true
+	}
+	return models.Money{}, false
+}
+
+func applyCartPromotion(promotions []models.Promotion, lines []*pricedLine) {
+	subtotal := models.Rupiah(0)
+	weights := make([]models.Money, len(lines))
+	for i, l := range lines {
+		weights[i] = l.net()
+		subtotal = subtotal.Add(weights[i])
+	}
+
+	var best *models.Promotion
+	bestAmount := models.Rupiah(0)
+	for i, p := range promotions {
+		if p.Type != models.PromotionTypeMinSpend || subtotal.LessThan(p.MinSpend) {
+			continue
+		}
+		amount := p.DiscountAmount
+		if p.DiscountPercent > 0 {
+			amount = subtotal.MulRatio(percentBasisPoints(p.DiscountPercent), 10000)
+		}
+		if subtotal.LessThan(amount) {
+			amount = subtotal
+		}
+		if bestAmount.LessThan(amount) {
+			best, bestAmount = &promotions[i], amount
+		}
+	}
+	if best == nil {
+		return
+	}
+
+	for i, amount := range allocate(bestAmount, weights) {
+		if amount.IsZero() {
+			continue
+		}
+		lines[i].discounts = append(lines[i].discounts, models.AppliedPromotion{PromotionID: best.ID, PromotionName: best.Name, Amount: amount})
+	}
+}
+
+// allocate membagi total secara proporsional terhadap weights. Sisa pembulatan masuk ke bobot terbesar
+// sehingga jumlah hasil selalu sama persis dengan total.
+func allocate(total models.Money, weights []models.Money) []models.Money {
+	sum := models.Rupiah(0)
+	largest := 0
+	for i, w := range weights {
+		sum = sum.Add(w)
+		if weights[largest].LessThan(w) {
+			largest = i
+		}
+	}
+
+	result := make([]models.Money, len(weights))
+	allocated := models.Rupiah(0)
+	for i, w := range weights {
+		result[i] = total.MulRatio(w.Amount, sum.Amount)
+		allocated = allocated.Add(result[i])
+	}
+	result[largest] = result[largest].Add(total.Sub(allocated))
+	return result
+}
+
+func percentBasisPoints(percent float64) int64 {
+	return int64(percent*100 + 0.5)
+}
+
+// activePromotions menyaring promosi yang jadwal jam/harinya berlaku pada waktu lokal toko,
+// diurutkan berdasarkan ID agar hasil perhitungan selalu sama.
+func activePromotions(promotions []models.Promotion, local time.Time) []models.Promotion {
+	active := make([]models.Promotion, 0, len(promotions))
+	for _, p := range promotions {
+		if inSchedule(p, local) {
+			active = append(active, p)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+	return active
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"kasir-api/models"
+	"testing"
+)
+
+func rupiahs(amounts ...int64) []models.Money {
+	m := make([]models.Money, len(amounts))
+	for i, a := range amounts {
+		m[i] = models.Rupiah(a)
+	}
+	return m
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{"proportional", 1000, []int64{2000, 1000}, []int64{667, 333}},
+		{"remainder to largest weight", 100, []int64{1000, 3000, 1000}, []int64{20, 60, 20}},
+		{"rounding remainder", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"remainder goes to largest, not first", 10, []int64{1, 5, 1}, []int64{1, 8, 1}},
+		{"zero weights", 50, []int64{0, 0}, []int64{50, 0}},
+		{"single line", 1234, []int64{5000}, []int64{1234}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocate(models.Rupiah(tt.total), rupiahs(tt.weights...))
+			sum := int64(0)
+			for i, g := range got {
+				sum += g.Amount
+				if g.Amount != tt.want[i] {
+					t.Errorf("allocate(%d, %v)[%d] = %d, want %d", tt.total, tt.weights, i, g.Amount, tt.want[i])
+				}
+			}
+			if sum != tt.total {
+				t.Errorf("allocate(%d, %v) sums to %d", tt.total, tt.weights, sum)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"time"
+)
+
+var ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+
+type PurchaseService struct {
+	repo          repository.PurchaseOrderRepository
+	storeLocation *time.Location
+}
+
+func NewPurchaseService(repo repository.PurchaseOrderRepository, storeLocation *time.Location) *PurchaseService {
+	return &PurchaseService{repo: repo, storeLocation: storeLocation}
+}
+
+func (s *PurchaseService) GetAll(supplierID int, status string) ([]models.PurchaseOrder, error) {
+	switch status {
+	case "", models.PurchaseOrderStatusOpen, models.PurchaseOrderStatusPartiallyReceived,
+		models.PurchaseOrderStatusReceived, models.PurchaseOrderStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidPurchaseOrder, status)
+	}
+	return s.repo.FetchAll(supplierID, status)
+}
+
+func (s *PurchaseService) GetByID(id int) (*models.PurchaseOrder, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *PurchaseService) Create(req models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidPurchaseOrder, msg) }
+
+	if req.ExpectedDate != nil {
+		if _, err := time.Parse("2006-01-02", *req.ExpectedDate); err != nil {
+			return nil, invalid("expected_date must be YYYY-MM-DD")
+		}
+	}
+	if len(req.Lines) == 0 {
+		return nil, invalid("lines must not be empty")
+	}
+	seen := make(map[int]bool, len(req.Lines))
+	for _, l := range req.Lines {
+		if l.Quantity <= 0 {
+			return nil, invalid("quantity must be greater than 0")
+		}
+		if l.UnitCost.IsNegative() {
+			return nil, invalid("unit_cost must not be negative")
+		}
+		if seen[l.ProductID] {
+			return nil, invalid(fmt.Sprintf("product %d appears more than once", l.ProductID))
+		}
+		seen[l.ProductID] = true
+	}
+
+	id, err := s.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *PurchaseService) Cancel(id int) (*models.PurchaseOrder, error) {
+	if err := s.repo.Cancel(id); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+// Receive mencatat penerimaan barang (parsial atau penuh) untuk purchase order.
+func (s *PurchaseService) Receive(id int, req models.GoodsReceiptRequest) (*models.PurchaseOrder, error) {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidPurchaseOrder, msg) }
+
+	if len(req.Lines) == 0 {
+		return nil, invalid("lines must not be empty")
+	}
+	seen := make(map[int]bool, len(req.Lines))
+	for _, l := range req.Lines {
+		if l.Quantity <= 0 {
+			return nil, invalid("quantity must be greater than 0")
+		}
+		if l.UnitCost != nil && l.UnitCost.IsNegative() {
+			return nil, invalid("unit_cost must not be negative")
+		}
+		if l.ExpiresAt != nil {
+			if _, err := time.Parse("2006-01-02", *l.ExpiresAt); err != nil {
+				return nil, invalid("expires_at must be YYYY-MM-DD")
+			}
+		}
+		if seen[l.ProductID] {
+			return nil, invalid(fmt.Sprintf("product %d appears more than once", l.ProductID))
+		}
+		seen[l.ProductID] = true
+	}
+
+	if _, err := s.repo.Receive(id, req); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+// GetPurchaseReport merekap pembelian per supplier dengan aturan tanggal yang sama seperti laporan penjualan.
+func (s *PurchaseService) GetPurchaseReport(startDate, endDate, tz string, supplierID int) (models.PurchaseReport, error) {
+	var query models.ReportQuery
+	var err error
+	query.Start, query.End, query.Location, err = dateRange(s.storeLocation, startDate, endDate, tz)
+	if err != nil {
+		return models.PurchaseReport{}, err
+	}
+
+	report, err := s.repo.GetPurchaseReport(query, supplierID)
+	if err != nil {
+		return report, err
+	}
+	report.Timezone = query.Location.String()
+	return report, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Pengiriman alert yang gagal dicoba ulang dengan jeda yang berlipat dua setiap kali, mulai dari
+// alertRetryDelay sampai maxAlertRetryDelay. Setelah maxAlertAttempts kali gagal alert tidak dicoba lagi.
+const (
+	maxAlertAttempts   = 5
+	alertRetryDelay    = time.Minute
+	maxAlertRetryDelay = time.Hour
+)
+
+// StockAlertNotifier mengirim alert stok menipis. Error membuat alert dikirim ulang pada pengecekan berikutnya.
+type StockAlertNotifier interface {
+	NotifyLowStock(alert models.LowStockAlert) error
+}
+
+// NewStockAlertNotifier memilih notifier sesuai konfigurasi.
+func NewStockAlertNotifier(cfg models.StockAlertConfig) StockAlertNotifier {
+	switch cfg.Notifier {
+	case models.StockNotifierWebhook:
+		return &WebhookNotifier{URL: cfg.WebhookURL, Client: &http.Client{Timeout: 10 * time.Second}}
+	case models.StockNotifierEmail:
+		return &EmailNotifier{To: cfg.EmailTo}
+	default:
+		return LogNotifier{}
+	}
+}
+
+// LogNotifier menulis alert ke log aplikasi.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyLowStock(a models.LowStockAlert) error {
+	log.Printf("Low stock: %s (product %d) stock %d, reorder point %d, reorder %d", a.ProductName, a.ProductID, a.Stock, a.ReorderPoint, a.ReorderQuantity)
+	return nil
+}
+
+// WebhookNotifier mengirim alert sebagai JSON lewat HTTP POST.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) NotifyLowStock(a models.LowStockAlert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	resp, err := n.Client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier belum terhubung ke server email; alert hanya dicatat di log sebagai email yang akan dikirim.
+type EmailNotifier struct {
+	To string
+}
+
+func (n *EmailNotifier) NotifyLowStock(a models.LowStockAlert) error {
+	log.Printf("Email to %s: [Stok menipis] %s tersisa %d (reorder point %d), pesan ulang %d", n.To, a.ProductName, a.Stock, a.ReorderPoint, a.ReorderQuantity)
+	return nil
+}
+
+type StockAlertService struct {
+	repo     repository.StockAlertRepository
+	notifier StockAlertNotifier
+}
+
+func NewStockAlertService(repo repository.StockAlertRepository, notifier StockAlertNotifier) *StockAlertService {
+	return &StockAlertService{repo: repo, notifier: notifier}
+}
+
+// Check membuat alert untuk penjualan baru yang melewati reorder point lalu mengirim alert yang belum terkirim.
+// Alert yang gagal dikirim dicatat dan dijadwalkan ulang tanpa menahan alert lain di belakangnya.
+func (s *StockAlertService) Check() error {
+	if _, err := s.repo.CollectAlerts(); err != nil {
+		return err
+	}
+	alerts, err := s.repo.FetchPending(100)
+	if err != nil {
+		return err
+	}
+	for _, a := range alerts {
+		if err := s.notifier.NotifyLowStock(a); err != nil {
+			if err := s.recordFailure(a, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.repo.MarkNotified(a.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StockAlertService) recordFailure(a models.LowStockAlert, notifyErr error) error {
+	attempts := a.Attempts + 1
+	if attempts >= maxAlertAttempts {
+		log.Printf("Giving up low stock alert %d after %d attempts: %v", a.ID, attempts, notifyErr)
+		return s.repo.RecordFailure(a.ID, notifyErr.Error(), nil)
+	}
+
+	delay := min(alertRetryDelay<<(attempts-1), maxAlertRetryDelay)
+	retryAt := time.Now().Add(delay)
+	log.Printf("Failed to notify low stock alert %d (attempt %d, retry in %s): %v", a.ID, attempts, delay, notifyErr)
+	return s.repo.RecordFailure(a.ID, notifyErr.Error(), &retryAt)
+}
+
+// StartLowStockChecker menjalankan Check secara berkala di background.
+func (s *StockAlertService) StartLowStockChecker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := s.Check(); err != nil {
+				log.Printf("Failed to check low stock: %v", err)
+			}
+		}
+	}()
+}
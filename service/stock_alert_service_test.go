@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+	"time"
+)
+
+type fakeAlertRepo struct {
+	pending  []models.LowStockAlert
+	notified []int
+	failures map[int]*time.Time
+}
+
+func (r *fakeAlertRepo) CollectAlerts() (int, error) { return 0, nil }
+
+func (r *fakeAlertRepo) FetchPending(limit int) ([]models.LowStockAlert, error) {
+	return r.pending, nil
+}
+
+func (r *fakeAlertRepo) MarkNotified(id int) error {
+	r.notified = append(r.notified, id)
+	return nil
+}
+
+func (r *fakeAlertRepo) RecordFailure(id int, message string, retryAt *time.Time) error {
+	r.failures[id] = retryAt
+	return nil
+}
+
+// failingNotifier gagal untuk alert yang ID-nya ada di failing.
+type failingNotifier struct {
+	failing map[int]bool
+}
+
+func (n failingNotifier) NotifyLowStock(a models.LowStockAlert) error {
+	if n.failing[a.ID] {
+		return errors.New("webhook unavailable")
+	}
+	return nil
+}
+
+func TestStockAlertCheckContinuesAfterFailure(t *testing.T) {
+	repo := &fakeAlertRepo{
+		pending: []models.LowStockAlert{
+			{ID: 1},
+			{ID: 2, Attempts: 1},
+			{ID: 3},
+			{ID: 4, Attempts: maxAlertAttempts - 1},
+		},
+		failures: make(map[int]*time.Time),
+	}
+	s := NewStockAlertService(repo, failingNotifier{failing: map[int]bool{1: true, 2: true, 4: true}})
+
+	before := time.Now()
+	if err := s.Check(); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	if len(repo.notified) != 1 || repo.notified[0] != 3 {
+		t.Errorf("notified = %v, want [3]", repo.notified)
+	}
+
+	// Jeda berlipat dua: percobaan pertama alertRetryDelay, kedua 2x alertRetryDelay
+	for id, wantDelay := range map[int]time.Duration{1: alertRetryDelay, 2: 2 * alertRetryDelay} {
+		retryAt, ok := repo.failures[id]
+		if !ok || retryAt == nil {
+			t.Errorf("alert %d: retry not scheduled", id)
+			continue
+		}
+		if delay := retryAt.Sub(before); delay < wantDelay || delay > wantDelay+time.Minute {
+			t.Errorf("alert %d: retry in %s, want about %s", id, delay, wantDelay)
+		}
+	}
+	if retryAt, ok := repo.failures[4]; !ok || retryAt != nil {
+		t.Errorf("alert 4: want given up (nil retry) after %d attempts, got %v", maxAlertAttempts, retryAt)
+	}
+}
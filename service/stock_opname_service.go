@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+)
+
+var ErrInvalidStockOpname = errors.New("invalid stock opname request")
+
+type StockOpnameService struct {
+	repo repository.StockOpnameRepository
+}
+
+func NewStockOpnameService(repo repository.StockOpnameRepository) *StockOpnameService {
+	return &StockOpnameService{repo: repo}
+}
+
+func (s *StockOpnameService) GetAll(status string) ([]models.StockOpname, error) {
+	switch status {
+	case "", models.StockOpnameStatusCounting, models.StockOpnameStatusApproved, models.StockOpnameStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidStockOpname, status)
+	}
+	return s.repo.FetchAll(status)
+}
+
+func (s *StockOpnameService) GetByID(id int) (*models.StockOpname, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *StockOpnameService) Create(req models.CreateStockOpnameRequest) (*models.StockOpname, error) {
+	id, err := s.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *StockOpnameService) SubmitCounts(id int, req models.StockOpnameCountRequest) (*models.StockOpname, error) {
+	if req.CountedBy == "" {
+		return nil, ErrUserRequired
+	}
+	if len(req.Counts) == 0 {
+		return nil, fmt.Errorf("%w: counts must not be empty", ErrInvalidStockOpname)
+	}
+	seen := make(map[int]bool, len(req.Counts))
+	for _, c := range req.Counts {
+		if c.Quantity < 0 {
+			return nil, fmt.Errorf("%w: quantity must not be negative", ErrInvalidStockOpname)
+		}
+		if seen[c.ProductID] {
+			return nil, fmt.Errorf("%w: product %d appears more than once", ErrInvalidStockOpname, c.ProductID)
+		}
+		seen[c.ProductID] = true
+	}
+
+	if err := s.repo.SubmitCounts(id, req); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *StockOpnameService) Approve(id int, user string) (*models.StockOpname, error) {
+	if user == "" {
+		return nil, ErrUserRequired
+	}
+	if err := s.repo.Approve(id, user); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *StockOpnameService) Cancel(id int, user string) (*models.StockOpname, error) {
+	if err := s.repo.Cancel(id, user); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByID(id)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"time"
+)
+
+var (
+	ErrInvalidStockQuery      = errors.New("invalid stock query")
+	ErrInvalidStockAdjustment = errors.New("invalid stock adjustment")
+)
+
+// Arah yang diizinkan per kode alasan: -1 hanya mengurangi, 1 hanya menambah, 0 keduanya
+var stockReasonSigns = map[string]int{
+	models.StockReasonDamaged:     -1,
+	models.StockReasonExpired:     -1,
+	models.StockReasonLost:        -1,
+	models.StockReasonFound:       1,
+	models.StockReasonCorrection:  0,
+	models.StockReasonTransferIn:  1,
+	models.StockReasonTransferOut: -1,
+	models.StockReasonOther:       0,
+}
+
+type StockService struct {
+	repo        repository.StockMovementRepository
+	productRepo repository.ProductRepository
+}
+
+func NewStockService(repo repository.StockMovementRepository, productRepo repository.ProductRepository) *StockService {
+	return &StockService{repo: repo, productRepo: productRepo}
+}
+
+// GetMovements mengambil riwayat pergerakan stok produk, terbaru lebih dulu.
+func (s *StockService) GetMovements(productID, limit, beforeID int) ([]models.StockMovement, error) {
+	if limit == 0 {
+		limit = 50
+	}
+	if limit < 0 || limit > 500 || beforeID < 0 {
+		return nil, ErrInvalidStockQuery
+	}
+	if _, err := s.productRepo.FetchByID(productID); err != nil {
+		return nil, err
+	}
+	return s.repo.FetchByProduct(productID, limit, beforeID)
+}
+
+func (s *StockService) Reconcile() (models.StockReconciliation, error) {
+	return s.repo.Reconcile()
+}
+
+// Adjust menambah atau mengurangi stok produk secara relatif dan mencatatnya di ledger.
+func (s *StockService) Adjust(productID int, req models.StockAdjustmentRequest) (*models.StockMovement, error) {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidStockAdjustment, msg) }
+
+	sign, ok := stockReasonSigns[req.Reason]
+	if !ok {
+		return nil, invalid("unknown reason " + req.Reason)
+	}
+	if req.Quantity == 0 {
+		return nil, invalid("quantity must not be 0")
+	}
+	if sign < 0 && req.Quantity > 0 {
+		return nil, invalid("quantity for reason " + req.Reason + " must be negative")
+	}
+	if sign > 0 && req.Quantity < 0 {
+		return nil, invalid("quantity for reason " + req.Reason + " must be positive")
+	}
+	if req.Reason == models.StockReasonOther && req.Note == "" {
+		return nil, invalid("note is required for reason other")
+	}
+
+	movement := models.StockMovement{
+		ProductID: productID,
+		Type:      models.StockMovementAdjustment,
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+		Note:      req.Note,
+		User:      req.User,
+	}
+	if req.Reason == models.StockReasonTransferIn || req.Reason == models.StockReasonTransferOut {
+		movement.Type = models.StockMovementTransfer
+	}
+
+	var newBatch *models.ProductBatch
+	if req.BatchNumber != "" || req.ExpiresAt != nil {
+		if req.BatchID != nil {
+			return nil, invalid("use either batch_id or batch_number/expires_at")
+		}
+		if req.Quantity < 0 {
+			return nil, invalid("a new batch can only be created by adding stock")
+		}
+		if req.ExpiresAt != nil {
+			if _, err := time.Parse("2006-01-02", *req.ExpiresAt); err != nil {
+				return nil, invalid("expires_at must be YYYY-MM-DD")
+			}
+		}
+		newBatch = &models.ProductBatch{BatchNumber: req.BatchNumber, ExpiresAt: req.ExpiresAt}
+	}
+	movement.BatchID = req.BatchID
+
+	if err := s.repo.Adjust(&movement, newBatch); err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
+
+func (s *StockService) GetLowStock() ([]models.LowStockProduct, error) {
+	return s.productRepo.FetchLowStock()
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"strings"
+)
+
+var ErrInvalidSupplier = errors.New("invalid supplier: name is required")
+
+type SupplierService struct {
+	repo repository.SupplierRepository
+}
+
+func NewSupplierService(repo repository.SupplierRepository) *SupplierService {
+	return &SupplierService{repo: repo}
+}
+
+func (s *SupplierService) GetAll() ([]models.Supplier, error) {
+	return s.repo.FetchAll()
+}
+
+func (s *SupplierService) GetByID(id int) (models.Supplier, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *SupplierService) Create(input *models.Supplier) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return ErrInvalidSupplier
+	}
+	return s.repo.Store(input)
+}
+
+func (s *SupplierService) Update(id int, input models.Supplier) (models.Supplier, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return models.Supplier{}, ErrInvalidSupplier
+	}
+
+	input.ID = id
+	if err := s.repo.Update(&input); err != nil {
+		return models.Supplier{}, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *SupplierService) Delete(id int) error {
+	_, err := s.repo.FetchByID(id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
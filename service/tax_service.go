@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+)
+
+var ErrInvalidTaxCategory = errors.New("invalid tax category")
+
+type TaxCategoryService struct {
+	repo repository.TaxCategoryRepository
+}
+
+func NewTaxCategoryService(repo repository.TaxCategoryRepository) *TaxCategoryService {
+	return &TaxCategoryService{repo: repo}
+}
+
+func (s *TaxCategoryService) GetAll() ([]models.TaxCategory, error) {
+	return s.repo.FetchAll()
+}
+
+func (s *TaxCategoryService) GetByID(id int) (models.TaxCategory, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *TaxCategoryService) Create(input *models.TaxCategory) error {
+	if input.Rate < 0 || input.Rate > 100 {
+		return fmt.Errorf("%w: rate must be between 0 and 100", ErrInvalidTaxCategory)
+	}
+	return s.repo.Store(input)
+}
+
+func (s *TaxCategoryService) Update(id int, input models.TaxCategory) (models.TaxCategory, error) {
+	if input.Rate < 0 || input.Rate > 100 {
+		return models.TaxCategory{}, fmt.Errorf("%w: rate must be between 0 and 100", ErrInvalidTaxCategory)
+	}
+
+	input.ID = id
+	if err := s.repo.Update(&input); err != nil {
+		return models.TaxCategory{}, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *TaxCategoryService) Delete(id int) error {
+	_, err := s.repo.FetchByID(id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// taxCalculator mengisi service charge dan PPN setiap baris checkout setelah diskon.
+// Pajak dihitung per baris lalu dijumlahkan, sehingga total transaksi selalu sama dengan jumlah barisnya.
+type taxCalculator struct {
+	config models.TaxConfig
+}
+
+func (c taxCalculator) apply(details []models.TransactionDetail) error {
+	serviceBps := percentBasisPoints(c.config.ServiceChargeRate)
+	for i := range details {
+		d := &details[i]
+
+		// Tarif baris: bebas pajak, kategori pajak produk, atau tarif default toko
+		switch {
+		case d.TaxExempt:
+			d.TaxRate = 0
+		case d.TaxCategoryID == 0:
+			d.TaxRate = c.config.DefaultRate
+		}
+		taxBps := percentBasisPoints(d.TaxRate)
+
+		// Harga sebelum pajak; untuk harga termasuk PPN, pajaknya dikeluarkan dulu
+		base, includedTax := d.Subtotal, models.Rupiah(0)
+		if c.config.PricesIncludeTax {
+			base = d.Subtotal.MulRatio(10000, 10000+taxBps)
+			includedTax = d.Subtotal.Sub(base)
+		}
+
+		d.ServiceCharge = base.MulRatio(serviceBps, 10000)
+		d.TaxableAmount = base.Add(d.ServiceCharge)
+		if c.config.PricesIncludeTax {
+			d.TaxAmount = includedTax.Add(d.ServiceCharge.MulRatio(taxBps, 10000))
+		} else {
+			d.TaxAmount = d.TaxableAmount.MulRatio(taxBps, 10000)
+		}
+		d.Total = d.TaxableAmount.Add(d.TaxAmount)
+	}
+	return nil
+}
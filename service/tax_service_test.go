@@ -0,0 +1,61 @@
+package service
+
+import (
+	"kasir-api/models"
+	"testing"
+)
+
+func TestTaxCalculatorApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		config models.TaxConfig
+		detail models.TransactionDetail
+		want   models.TransactionDetail // Hanya field pajak yang dibandingkan
+	}{
+		{
+			name:   "price excludes tax with service charge",
+			config: models.TaxConfig{DefaultRate: 11, ServiceChargeRate: 5},
+			detail: models.TransactionDetail{Subtotal: models.Rupiah(10000)},
+			want:   models.TransactionDetail{TaxRate: 11, ServiceCharge: models.Rupiah(500), TaxableAmount: models.Rupiah(10500), TaxAmount: models.Rupiah(1155), Total: models.Rupiah(11655)},
+		},
+		{
+			name:   "price includes tax",
+			config: models.TaxConfig{DefaultRate: 11, PricesIncludeTax: true},
+			detail: models.TransactionDetail{Subtotal: models.Rupiah(11100)},
+			want:   models.TransactionDetail{TaxRate: 11, ServiceCharge: models.Rupiah(0), TaxableAmount: models.Rupiah(10000), TaxAmount: models.Rupiah(1100), Total: models.Rupiah(11100)},
+		},
+		{
+			name:   "price includes tax with service charge",
+			config: models.TaxConfig{DefaultRate: 11, PricesIncludeTax: true, ServiceChargeRate: 10},
+			detail: models.TransactionDetail{Subtotal: models.Rupiah(11100)},
+			want:   models.TransactionDetail{TaxRate: 11, ServiceCharge: models.Rupiah(1000), TaxableAmount: models.Rupiah(11000), TaxAmount: models.Rupiah(1210), Total: models.Rupiah(12210)},
+		},
+		{
+			name:   "tax category rate is kept",
+			config: models.TaxConfig{DefaultRate: 11},
+			detail: models.TransactionDetail{Subtotal: models.Rupiah(999), TaxCategoryID: 3, TaxRate: 5},
+			want:   models.TransactionDetail{TaxRate: 5, ServiceCharge: models.Rupiah(0), TaxableAmount: models.Rupiah(999), TaxAmount: models.Rupiah(50), Total: models.Rupiah(1049)},
+		},
+		{
+			name:   "tax exempt",
+			config: models.TaxConfig{DefaultRate: 11, PricesIncludeTax: true},
+			detail: models.TransactionDetail{Subtotal: models.Rupiah(5000), TaxExempt: true, TaxCategoryID: 3, TaxRate: 5},
+			want:   models.TransactionDetail{TaxRate: 0, ServiceCharge: models.Rupiah(0), TaxableAmount: models.Rupiah(5000), TaxAmount: models.Rupiah(0), Total: models.Rupiah(5000)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := []models.TransactionDetail{tt.detail}
+			if err := (taxCalculator{config: tt.config}).apply(details); err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			got := details[0]
+			if got.TaxRate != tt.want.TaxRate || got.ServiceCharge != tt.want.ServiceCharge || got.TaxableAmount != tt.want.TaxableAmount ||
+				got.TaxAmount != tt.want.TaxAmount || got.Total != tt.want.Total {
+				t.Errorf("apply = rate %v, service %s, taxable %s, tax %s, total %s; want rate %v, service %s, taxable %s, tax %s, total %s",
+					got.TaxRate, got.ServiceCharge, got.TaxableAmount, got.TaxAmount, got.Total,
+					tt.want.TaxRate, tt.want.ServiceCharge, tt.want.TaxableAmount, tt.want.TaxAmount, tt.want.Total)
+			}
+		})
+	}
+}
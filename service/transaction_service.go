@@ -1,27 +1,324 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"kasir-api/models"
 	"kasir-api/repository"
+	"strings"
 	"time"
 )
 
+const (
+	defaultTransactionPageSize = 20
+	maxTransactionPageSize     = 100
+	defaultReportTopN          = 5
+	maxReportTopN              = 50
+)
+
+var (
+	ErrInvalidCheckout = errors.New("invalid checkout request")
+	ErrUserRequired    = errors.New("X-User header is required")
+	ErrInvalidFilter   = errors.New("invalid filter")
+)
+
 type TransactionService struct {
-	repo repository.TransactionRepository
+	repo          repository.TransactionRepository
+	promotionRepo repository.PromotionRepository
+	storeLocation *time.Location
+	taxConfig     models.TaxConfig
 }
 
-func NewTransactionService(repo repository.TransactionRepository) *TransactionService {
-	return &TransactionService{repo: repo}
+func NewTransactionService(repo repository.TransactionRepository, promotionRepo repository.PromotionRepository, storeLocation *time.Location, taxConfig models.TaxConfig) *TransactionService {
+	return &TransactionService{repo: repo, promotionRepo: promotionRepo, storeLocation: storeLocation, taxConfig: taxConfig}
 }
 
-func (s *TransactionService) Checkout(items []models.CheckoutItem) (*models.Transaction, error) {
-	return s.repo.CreateTransaction(items)
+// StoreLocation adalah zona waktu toko, dipakai untuk menafsirkan tanggal tanpa jam.
+func (s *TransactionService) StoreLocation() *time.Location {
+	return s.storeLocation
 }
 
-func (s *TransactionService) GetDailyReport() (models.SalesReport, error) {
+func (s *TransactionService) Checkout(req models.CheckoutRequest) (*models.Transaction, error) {
+	if req.IdempotencyKey != "" {
+		// Hash body request agar replay dengan isi berbeda bisa ditolak
+		body, err := json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(body)
+		req.RequestHash = hex.EncodeToString(sum[:])
+	}
+
+	items, err := s.resolveBarcodes(req.Items)
+	if err != nil {
+		return nil, err
+	}
+	if items, err = mergeCheckoutItems(items); err != nil {
+		return nil, err
+	}
+	req.Items = items
+
+	if err := validatePayments(req.Payments); err != nil {
+		return nil, err
+	}
+
+	// Promosi dievaluasi terhadap harga yang dikunci di dalam transaksi database
 	now := time.Now()
-	startDate := now.Format("2006-01-02") + " 00:00:00"
-	endDate := now.Format("2006-01-02") + " 23:59:59"
+	promotions, err := s.promotionRepo.FetchActive(now)
+	if err != nil {
+		return nil, err
+	}
+	engine := promotionEngine{promotions: activePromotions(promotions, now.In(s.storeLocation))}
+	tax := taxCalculator{config: s.taxConfig}
+	req.PricesIncludeTax = s.taxConfig.PricesIncludeTax
+	req.BusinessDate = now.In(s.storeLocation).Format("2006-01-02")
+	req.VoucherCode = normalizeVoucherCode(req.VoucherCode)
+	req.Customer = strings.TrimSpace(req.Customer)
+
+	// Urutan: promosi, voucher, lalu pajak dan service charge dari harga setelah diskon
+	return s.repo.CreateTransaction(req, func(pricing *repository.CheckoutPricing) error {
+		if err := engine.apply(pricing.Details); err != nil {
+			return err
+		}
+		if req.VoucherCode != "" {
+			if err := applyVoucher(pricing, req.VoucherCode, req.Customer, now); err != nil {
+				return err
+			}
+		}
+		return tax.apply(pricing.Details)
+	})
+}
+
+func validatePayments(payments []models.PaymentRequest) error {
+	for _, p := range payments {
+		switch p.Method {
+		case models.PaymentMethodCash, models.PaymentMethodDebitCard, models.PaymentMethodQRIS,
+			models.PaymentMethodEWallet, models.PaymentMethodVoucher:
+		default:
+			return fmt.Errorf("%w: unknown payment method %q", ErrInvalidCheckout, p.Method)
+		}
+		if p.Amount.Amount <= 0 {
+			return fmt.Errorf("%w: payment amount must be greater than 0", ErrInvalidCheckout)
+		}
+	}
+	return nil
+}
+
+// resolveBarcodes mengganti barcode hasil scan dengan ID produknya. Barcode dianggap variant_id
+// sehingga product_id yang ikut dikirim tetap dicek sebagai induknya.
+func (s *TransactionService) resolveBarcodes(items []models.CheckoutItem) ([]models.CheckoutItem, error) {
+	codes := make([]string, 0)
+	for _, item := range items {
+		if item.Barcode != "" {
+			codes = append(codes, item.Barcode)
+		}
+	}
+	if len(codes) == 0 {
+		return items, nil
+	}
+
+	products, err := s.repo.ResolveBarcodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]models.CheckoutItem, len(items))
+	for i, item := range items {
+		if item.Barcode != "" {
+			productID, ok := products[item.Barcode]
+			if !ok {
+				return nil, fmt.Errorf("%w: barcode %s not found", ErrInvalidCheckout, item.Barcode)
+			}
+			if item.VariantID != 0 && item.VariantID != productID {
+				return nil, fmt.Errorf("%w: barcode %s does not belong to variant id %d", ErrInvalidCheckout, item.Barcode, item.VariantID)
+			}
+			item.VariantID = productID
+			item.Barcode = ""
+		}
+		resolved[i] = item
+	}
+	return resolved, nil
+}
+
+// mergeCheckoutItems menolak quantity <= 0 dan menggabungkan product_id yang muncul lebih dari sekali
+// dengan satuan yang sama. Item dengan variant_id dihitung sebagai produk varian tersebut.
+func mergeCheckoutItems(items []models.CheckoutItem) ([]models.CheckoutItem, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: items is empty", ErrInvalidCheckout)
+	}
+
+	merged := make([]models.CheckoutItem, 0, len(items))
+	index := make(map[int]int)
+	for _, item := range items {
+		if item.VariantID != 0 {
+			if item.ProductID != 0 && item.ProductID != item.VariantID {
+				item.ParentID = item.ProductID
+			}
+			item.ProductID = item.VariantID
+			item.VariantID = 0
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity for product id %d must be greater than 0", ErrInvalidCheckout, item.ProductID)
+		}
+		if i, ok := index[item.ProductID]; ok {
+			if merged[i].Unit != item.Unit {
+				return nil, fmt.Errorf("%w: product id %d must use a single unit per checkout", ErrInvalidCheckout, item.ProductID)
+			}
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged, nil
+}
+
+func (s *TransactionService) GetAll(filter models.TransactionFilter) (models.TransactionPage, error) {
+	if filter.Sort == "" {
+		filter.Sort = "-created_at"
+	}
+	switch strings.TrimPrefix(filter.Sort, "-") {
+	case "created_at", "total_amount":
+	default:
+		return models.TransactionPage{}, fmt.Errorf("%w: sort must be created_at or total_amount, optionally prefixed with -", ErrInvalidFilter)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransactionPageSize
+	}
+	if filter.Limit > maxTransactionPageSize {
+		filter.Limit = maxTransactionPageSize
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		return models.TransactionPage{}, fmt.Errorf("%w: start_date must be before end_date", ErrInvalidFilter)
+	}
+
+	return s.repo.FetchAll(filter)
+}
+
+func (s *TransactionService) GetByID(id int) (*models.Transaction, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *TransactionService) Void(id int, req models.VoidRequest) (*models.Refund, error) {
+	if req.User == "" {
+		return nil, ErrUserRequired
+	}
+	return s.repo.VoidTransaction(id, req)
+}
+
+func (s *TransactionService) Refund(id int, req models.RefundRequest) (*models.Refund, error) {
+	if req.User == "" {
+		return nil, ErrUserRequired
+	}
+	return s.repo.CreateRefund(id, req)
+}
+
+// GetReport membuat laporan untuk tanggal startDate s.d. endDate (YYYY-MM-DD, keduanya inklusif)
+// menurut zona waktu tz. Rentang yang dikirim ke repository selalu [awal startDate, awal hari setelah endDate).
+// tz kosong berarti zona waktu toko; startDate kosong berarti hari ini; endDate kosong berarti sama dengan startDate.
+// sections memilih bagian tambahan (lihat models.ReportSection*), "all" berarti semua bagian.
+func (s *TransactionService) GetReport(startDate, endDate, tz string, sections []string, topN int) (models.SalesReport, error) {
+	query := models.ReportQuery{Sections: make(map[string]bool), TopN: topN}
+	for _, section := range sections {
+		switch section {
+		case "all":
+			query.Sections[models.ReportSectionTopProducts] = true
+			query.Sections[models.ReportSectionCategories] = true
+			query.Sections[models.ReportSectionBasket] = true
+			query.Sections[models.ReportSectionHourly] = true
+			query.Sections[models.ReportSectionProfit] = true
+		case models.ReportSectionTopProducts, models.ReportSectionCategories,
+			models.ReportSectionBasket, models.ReportSectionHourly, models.ReportSectionProfit:
+			query.Sections[section] = true
+		default:
+			return models.SalesReport{}, fmt.Errorf("%w: unknown section %q", ErrInvalidFilter, section)
+		}
+	}
+	if query.TopN <= 0 {
+		query.TopN = defaultReportTopN
+	}
+	if query.TopN > maxReportTopN {
+		query.TopN = maxReportTopN
+	}
+
+	var err error
+	query.Start, query.End, query.Location, err = s.reportRange(startDate, endDate, tz)
+	if err != nil {
+		return models.SalesReport{}, err
+	}
+
+	report, err := s.repo.GetSalesReport(query)
+	if err != nil {
+		return report, err
+	}
+	report.Timezone = query.Location.String()
+	return report, nil
+}
+
+func (s *TransactionService) GetDailyReport() (models.SalesReport, error) {
+	return s.GetReport("", "", "", nil, 0)
+}
+
+// GetTaxSummary merekap pajak untuk tanggal startDate s.d. endDate dengan aturan tanggal yang sama seperti GetReport.
+func (s *TransactionService) GetTaxSummary(startDate, endDate, tz string) (models.TaxSummary, error) {
+	var query models.ReportQuery
+	var err error
+	query.Start, query.End, query.Location, err = s.reportRange(startDate, endDate, tz)
+	if err != nil {
+		return models.TaxSummary{}, err
+	}
+
+	summary, err := s.repo.GetTaxSummary(query)
+	if err != nil {
+		return summary, err
+	}
+	summary.Timezone = query.Location.String()
+	return summary, nil
+}
+
+func (s *TransactionService) reportRange(startDate, endDate, tz string) (time.Time, time.Time, *time.Location, error) {
+	return dateRange(s.storeLocation, startDate, endDate, tz)
+}
+
+// dateRange mengubah tanggal YYYY-MM-DD (keduanya inklusif) menjadi rentang [start, end) pada zona waktu tz,
+// atau zona waktu toko jika tz kosong.
+func dateRange(storeLocation *time.Location, startDate, endDate, tz string) (time.Time, time.Time, *time.Location, error) {
+	loc := storeLocation
+	if tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidFilter, tz)
+		}
+	}
+
+	var start time.Time
+	if startDate == "" {
+		now := time.Now().In(loc)
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	} else {
+		var err error
+		start, err = time.ParseInLocation("2006-01-02", startDate, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, fmt.Errorf("%w: start_date must be YYYY-MM-DD", ErrInvalidFilter)
+		}
+	}
 
-	return s.repo.GetSalesReport(startDate, endDate)
+	last := start
+	if endDate != "" {
+		var err error
+		last, err = time.ParseInLocation("2006-01-02", endDate, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, fmt.Errorf("%w: end_date must be YYYY-MM-DD", ErrInvalidFilter)
+		}
+	}
+	if last.Before(start) {
+		return time.Time{}, time.Time{}, nil, fmt.Errorf("%w: end_date must not be before start_date", ErrInvalidFilter)
+	}
+	// AddDate menjaga batas tengah malam di zona waktu loc
+	return start, last.AddDate(0, 0, 1), loc, nil
 }
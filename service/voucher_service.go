@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repository"
+	"strings"
+	"time"
+)
+
+var ErrInvalidVoucher = errors.New("invalid voucher")
+
+type VoucherService struct {
+	repo repository.VoucherRepository
+}
+
+func NewVoucherService(repo repository.VoucherRepository) *VoucherService {
+	return &VoucherService{repo: repo}
+}
+
+func (s *VoucherService) GetAll() ([]models.Voucher, error) {
+	return s.repo.FetchAll()
+}
+
+func (s *VoucherService) GetByID(id int) (models.Voucher, error) {
+	return s.repo.FetchByID(id)
+}
+
+func (s *VoucherService) Create(input *models.Voucher) error {
+	if err := validateVoucher(input); err != nil {
+		return err
+	}
+	return s.repo.Store(input)
+}
+
+func (s *VoucherService) Update(id int, input models.Voucher) (models.Voucher, error) {
+	if err := validateVoucher(&input); err != nil {
+		return models.Voucher{}, err
+	}
+
+	input.ID = id
+	if err := s.repo.Update(&input); err != nil {
+		return models.Voucher{}, err
+	}
+	return s.repo.FetchByID(id)
+}
+
+func (s *VoucherService) Delete(id int) error {
+	_, err := s.repo.FetchByID(id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// normalizeVoucherCode membuat kode voucher tidak peka huruf besar/kecil dan spasi di tepi.
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateVoucher(v *models.Voucher) error {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidVoucher, msg) }
+
+	v.Code = normalizeVoucherCode(v.Code)
+	if v.Code == "" {
+		return invalid("code is required")
+	}
+	switch v.Type {
+	case models.VoucherTypePercentage:
+		if v.DiscountPercent <= 0 || v.DiscountPercent > 100 {
+			return invalid("discount_percent must be between 0 and 100")
+		}
+	case models.VoucherTypeFixed:
+		if v.DiscountAmount.Amount <= 0 {
+			return invalid("discount_amount must be greater than 0")
+		}
+	default:
+		return invalid("type must be percentage or fixed")
+	}
+	if v.MaxDiscount.IsNegative() || v.MinPurchase.IsNegative() || v.MaxUses < 0 || v.MaxUsesPerCustomer < 0 {
+		return invalid("max_discount, min_purchase and usage limits must not be negative")
+	}
+	if v.StartsAt != nil && v.ExpiresAt != nil && !v.StartsAt.Before(*v.ExpiresAt) {
+		return invalid("starts_at must be before expires_at")
+	}
+	return nil
+}
+
+// applyVoucher memeriksa syarat voucher yang sudah dikunci repository lalu membagi potongannya
+// ke setiap baris secara proporsional. Dipanggil setelah promosi dan sebelum pajak.
+func applyVoucher(pricing *repository.CheckoutPricing, code, customer string, now time.Time) error {
+	invalid := func(msg string) error { return fmt.Errorf("%w: %s", ErrInvalidVoucher, msg) }
+
+	v := pricing.Voucher
+	switch {
+	case v == nil:
+		return invalid("voucher " + code + " not found")
+	case !v.Active:
+		return invalid("voucher is not active")
+	case v.StartsAt != nil && now.Before(*v.StartsAt):
+		return invalid("voucher is not valid yet")
+	case v.ExpiresAt != nil && !now.Before(*v.ExpiresAt):
+		return invalid("voucher has expired")
+	case v.MaxUses > 0 && v.UsedCount >= v.MaxUses:
+		return invalid("voucher usage limit reached")
+	case v.MaxUsesPerCustomer > 0 && customer == "":
+		return invalid("customer is required for this voucher")
+	case v.MaxUsesPerCustomer > 0 && pricing.CustomerRedemptions >= v.MaxUsesPerCustomer:
+		return invalid("voucher usage limit for this customer reached")
+	}
+
+	purchase := models.Rupiah(0)
+	weights := make([]models.Money, len(pricing.Details))
+	for i, d := range pricing.Details {
+		weights[i] = d.Subtotal
+		purchase = purchase.Add(d.Subtotal)
+	}
+	if purchase.LessThan(v.MinPurchase) {
+		return invalid(fmt.Sprintf("minimum purchase is %s", v.MinPurchase))
+	}
+
+	discount := v.DiscountAmount
+	if v.Type == models.VoucherTypePercentage {
+		discount = purchase.MulRatio(percentBasisPoints(v.DiscountPercent), 10000)
+		if v.MaxDiscount.Amount > 0 && v.MaxDiscount.LessThan(discount) {
+			discount = v.MaxDiscount
+		}
+	}
+	if purchase.LessThan(discount) {
+		discount = purchase
+	}
+
+	for i, amount := range allocate(discount, weights) {
+		d := &pricing.Details[i]
+		d.VoucherDiscount = amount
+		d.DiscountAmount = d.DiscountAmount.Add(amount)
+		d.Subtotal = d.Subtotal.Sub(amount)
+	}
+	pricing.VoucherDiscount = discount
+	return nil
+}